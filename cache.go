@@ -0,0 +1,83 @@
+package exchange
+
+import "time"
+
+// Cache is implemented by a pluggable rate-cache backend. Get reports
+// whether key was found and is still valid; Set stores value for ttl
+// (zero meaning "never expires"). Implementations must be safe for
+// concurrent use.
+type Cache interface {
+	Get(key string) (interface{}, bool)
+	Set(key string, value interface{}, ttl time.Duration)
+}
+
+// cacheKind identifies which endpoint a cached response belongs to, so
+// CachePolicy can pick the right TTL for it.
+type cacheKind int
+
+const (
+	cacheKindLatest cacheKind = iota
+	cacheKindHistorical
+	cacheKindTimeseries
+	cacheKindFluctuation
+	cacheKindSymbols
+)
+
+// CachePolicy wraps a Cache and assigns a TTL per cacheKind: historical,
+// timeseries and fluctuation responses are cached forever once the day(s)
+// they describe have closed (they cannot change after the fact), latest
+// is cached until UTC midnight same as before this policy layer existed,
+// and symbols / cryptocurrencies are cached for SymbolsTTL.
+type CachePolicy struct {
+	Cache Cache
+	// SymbolsTTL is how long /symbols and /cryptocurrencies responses are
+	// kept; it defaults to 24h if zero.
+	SymbolsTTL time.Duration
+}
+
+// ttl picks the TTL for kind. end is the last date the response
+// describes (q.Date for historical, q.TimeFrame[1] for timeseries and
+// fluctuation); if that date hasn't closed yet in UTC, the response is
+// provisional and gets the same midnight expiry as latest instead of
+// being cached forever.
+func (p *CachePolicy) ttl(kind cacheKind, end string) time.Duration {
+	switch kind {
+	case cacheKindHistorical, cacheKindTimeseries, cacheKindFluctuation:
+		if end == "" || !dateHasClosed(end) {
+			return cacheDuration()
+		}
+		return 0 // forever
+	case cacheKindSymbols:
+		if p.SymbolsTTL == 0 {
+			return 24 * time.Hour
+		}
+		return p.SymbolsTTL
+	default: // cacheKindLatest
+		return cacheDuration()
+	}
+}
+
+// dateHasClosed reports whether date (YYYY-MM-DD) names a day that has
+// already ended in UTC, i.e. is strictly before today.
+func dateHasClosed(date string) bool {
+	t, err := parseDate(date)
+	if err != nil {
+		return false
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	return t.Before(today)
+}
+
+func (p *CachePolicy) get(key string) (interface{}, bool) {
+	if p == nil || p.Cache == nil {
+		return nil, false
+	}
+	return p.Cache.Get(key)
+}
+
+func (p *CachePolicy) set(key string, value interface{}, kind cacheKind, end string) {
+	if p == nil || p.Cache == nil {
+		return
+	}
+	p.Cache.Set(key, value, p.ttl(kind, end))
+}
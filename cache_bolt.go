@@ -0,0 +1,90 @@
+package exchange
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var errBoltCacheMiss = errors.New("exchange: key not found in bolt cache")
+
+const boltCacheBucket = "exchange_rate_cache"
+
+// BoltCache is a file-backed Cache using BoltDB, for long-lived services
+// or backtests that shouldn't re-fetch immutable historical rates on
+// every restart.
+type BoltCache struct {
+	db *bolt.DB
+}
+
+type boltCacheEntry struct {
+	Value     json.RawMessage `json:"value"`
+	ExpiresAt time.Time       `json:"expires_at"` // zero means "never"
+}
+
+// NewBoltCache opens (creating if necessary) a BoltDB file at path for use
+// as a Cache.
+func NewBoltCache(path string) (*BoltCache, error) {
+	db, err := bolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(boltCacheBucket))
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &BoltCache{db: db}, nil
+}
+
+// Close releases the underlying BoltDB file handle.
+func (c *BoltCache) Close() error {
+	return c.db.Close()
+}
+
+// Get implements Cache.
+func (c *BoltCache) Get(key string) (interface{}, bool) {
+	var entry boltCacheEntry
+	err := c.db.View(func(tx *bolt.Tx) error {
+		raw := tx.Bucket([]byte(boltCacheBucket)).Get([]byte(key))
+		if raw == nil {
+			return errBoltCacheMiss
+		}
+		return json.Unmarshal(raw, &entry)
+	})
+	if err != nil {
+		return nil, false
+	}
+	if !entry.ExpiresAt.IsZero() && time.Now().After(entry.ExpiresAt) {
+		return nil, false
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(entry.Value, &value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// Set implements Cache.
+func (c *BoltCache) Set(key string, value interface{}, ttl time.Duration) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	entry := boltCacheEntry{Value: raw}
+	if ttl > 0 {
+		entry.ExpiresAt = time.Now().Add(ttl)
+	}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	_ = c.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(boltCacheBucket)).Put([]byte(key), b)
+	})
+}
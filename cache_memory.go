@@ -0,0 +1,33 @@
+package exchange
+
+import (
+	"time"
+
+	gocache "github.com/patrickmn/go-cache"
+)
+
+// MemoryCache is an in-process Cache backed by go-cache. It's the simplest
+// Cache implementation and, like the unconfigured default, doesn't survive
+// a process restart; use BoltCache or SQLCache for that.
+type MemoryCache struct {
+	cache *gocache.Cache
+}
+
+// NewMemoryCache returns a MemoryCache that sweeps expired entries every
+// cleanupInterval.
+func NewMemoryCache(cleanupInterval time.Duration) *MemoryCache {
+	return &MemoryCache{cache: gocache.New(gocache.NoExpiration, cleanupInterval)}
+}
+
+// Get implements Cache.
+func (c *MemoryCache) Get(key string) (interface{}, bool) {
+	return c.cache.Get(key)
+}
+
+// Set implements Cache.
+func (c *MemoryCache) Set(key string, value interface{}, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = gocache.NoExpiration
+	}
+	c.cache.Set(key, value, ttl)
+}
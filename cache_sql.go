@@ -0,0 +1,68 @@
+package exchange
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// SQLCacheSchema is the table SQLCache expects to already exist, in the
+// spirit of the simple key/value-with-expiry migrations used elsewhere for
+// persistent trading-bot state: one row per cache key, a NULL expires_at
+// meaning "never".
+const SQLCacheSchema = `
+CREATE TABLE IF NOT EXISTS exchange_rate_cache (
+	cache_key  TEXT PRIMARY KEY,
+	value      TEXT NOT NULL,
+	expires_at TIMESTAMP NULL
+);
+`
+
+// SQLCache is a Cache backed by a SQL table (see SQLCacheSchema), for
+// services that already keep their state in a database and want rate
+// lookups to survive restarts.
+type SQLCache struct {
+	db *sql.DB
+}
+
+// NewSQLCache returns a SQLCache using db, which must already have the
+// table described by SQLCacheSchema.
+func NewSQLCache(db *sql.DB) *SQLCache {
+	return &SQLCache{db: db}
+}
+
+// Get implements Cache.
+func (c *SQLCache) Get(key string) (interface{}, bool) {
+	var raw string
+	var expiresAt sql.NullTime
+	err := c.db.QueryRow(`SELECT value, expires_at FROM exchange_rate_cache WHERE cache_key = ?`, key).
+		Scan(&raw, &expiresAt)
+	if err != nil {
+		return nil, false
+	}
+	if expiresAt.Valid && time.Now().After(expiresAt.Time) {
+		return nil, false
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// Set implements Cache.
+func (c *SQLCache) Set(key string, value interface{}, ttl time.Duration) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	var expiresAt sql.NullTime
+	if ttl > 0 {
+		expiresAt = sql.NullTime{Time: time.Now().Add(ttl), Valid: true}
+	}
+	_, _ = c.db.Exec(`
+		INSERT INTO exchange_rate_cache (cache_key, value, expires_at) VALUES (?, ?, ?)
+		ON CONFLICT(cache_key) DO UPDATE SET value = excluded.value, expires_at = excluded.expires_at
+	`, key, string(raw), expiresAt)
+}
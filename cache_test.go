@@ -0,0 +1,82 @@
+package exchange
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCachePolicyTTLClosedDateIsForever(t *testing.T) {
+	p := &CachePolicy{}
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02")
+
+	for _, kind := range []cacheKind{cacheKindHistorical, cacheKindTimeseries, cacheKindFluctuation} {
+		if ttl := p.ttl(kind, yesterday); ttl != 0 {
+			t.Errorf("ttl(%v, %s) = %v, want 0 (forever) for a closed date", kind, yesterday, ttl)
+		}
+	}
+}
+
+func TestCachePolicyTTLTodayIsNotForever(t *testing.T) {
+	p := &CachePolicy{}
+	today := time.Now().UTC().Format("2006-01-02")
+
+	for _, kind := range []cacheKind{cacheKindHistorical, cacheKindTimeseries, cacheKindFluctuation} {
+		if ttl := p.ttl(kind, today); ttl <= 0 {
+			t.Errorf("ttl(%v, %s) = %v, want a positive midnight-expiry TTL for today (not yet closed)", kind, today, ttl)
+		}
+	}
+}
+
+func TestCachePolicyTTLMissingEndIsNotForever(t *testing.T) {
+	p := &CachePolicy{}
+	if ttl := p.ttl(cacheKindHistorical, ""); ttl <= 0 {
+		t.Errorf("ttl(cacheKindHistorical, \"\") = %v, want a positive TTL rather than forever", ttl)
+	}
+}
+
+func TestCachePolicyTTLSymbolsDefaultsTo24h(t *testing.T) {
+	p := &CachePolicy{}
+	if ttl := p.ttl(cacheKindSymbols, ""); ttl != 24*time.Hour {
+		t.Errorf("ttl(cacheKindSymbols) = %v, want 24h", ttl)
+	}
+
+	p2 := &CachePolicy{SymbolsTTL: time.Hour}
+	if ttl := p2.ttl(cacheKindSymbols, ""); ttl != time.Hour {
+		t.Errorf("ttl(cacheKindSymbols) with override = %v, want 1h", ttl)
+	}
+}
+
+func TestCachePolicyGetSetRoundTrip(t *testing.T) {
+	p := &CachePolicy{Cache: NewMemoryCache(time.Minute)}
+
+	if _, ok := p.get("missing"); ok {
+		t.Fatal("expected cache miss on unset key")
+	}
+
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02")
+	p.set("key", "value", cacheKindHistorical, yesterday)
+
+	got, ok := p.get("key")
+	if !ok {
+		t.Fatal("expected cache hit after set")
+	}
+	if got != "value" {
+		t.Errorf("got %v, want %q", got, "value")
+	}
+}
+
+func TestDateHasClosed(t *testing.T) {
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02")
+	today := time.Now().UTC().Format("2006-01-02")
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format("2006-01-02")
+
+	if !dateHasClosed(yesterday) {
+		t.Errorf("dateHasClosed(%s) = false, want true", yesterday)
+	}
+	if dateHasClosed(today) {
+		t.Errorf("dateHasClosed(%s) = true, want false", today)
+	}
+	if dateHasClosed(tomorrow) {
+		t.Errorf("dateHasClosed(%s) = true, want false", tomorrow)
+	}
+}
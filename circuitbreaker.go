@@ -0,0 +1,72 @@
+package exchange
+
+import (
+	"sync"
+	"time"
+)
+
+// CircuitBreaker opens after Threshold consecutive failures and stays open
+// for Cooldown, short-circuiting further requests to ErrProviderUnavailable
+// without hitting the network, then closes again on the next success.
+type CircuitBreaker struct {
+	// Threshold is how many consecutive failures trip the breaker.
+	// Defaults to 5 if zero.
+	Threshold int
+	// Cooldown is how long the breaker stays open once tripped.
+	// Defaults to 30s if zero.
+	Cooldown time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+func (b *CircuitBreaker) threshold() int {
+	if b.Threshold <= 0 {
+		return 5
+	}
+	return b.Threshold
+}
+
+func (b *CircuitBreaker) cooldown() time.Duration {
+	if b.Cooldown <= 0 {
+		return 30 * time.Second
+	}
+	return b.Cooldown
+}
+
+// Allow reports whether a request may proceed, i.e. the breaker isn't
+// currently open.
+func (b *CircuitBreaker) Allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return time.Now().After(b.openUntil)
+}
+
+// RecordSuccess closes the breaker and resets the failure count.
+func (b *CircuitBreaker) RecordSuccess() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.openUntil = time.Time{}
+}
+
+// RecordFailure counts a failure, opening the breaker for Cooldown once
+// Threshold consecutive failures have been recorded.
+func (b *CircuitBreaker) RecordFailure() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.failures >= b.threshold() {
+		b.openUntil = time.Now().Add(b.cooldown())
+	}
+}
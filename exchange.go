@@ -1,19 +1,12 @@
 package exchange
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"io"
 	"math/big"
-	"net/http"
-	"os"
 	"sort"
-	"strconv"
-	"strings"
 	"time"
-
-	gocache "github.com/patrickmn/go-cache"
 )
 
 // ErrInvalidCode is returned when the currency code is invalid
@@ -34,22 +27,22 @@ var ErrInvalidTimeFrame = errors.New("From date must be older than To date")
 // ErrInvalidAPIResponse is returned when the API return success: false
 var ErrInvalidAPIResponse = errors.New("Unknown API error")
 
-const (
-	baseURL             string = "https://api.exchangerate.host"
-	symbolsURL          string = baseURL + "/symbols"
-	cryptocurrenciesURL string = baseURL + "/cryptocurrencies"
-	latestURL           string = baseURL + "/latest"
-	convertURL          string = baseURL + "/convert"
-	historicalURL       string = baseURL + "/"
-	timeseriesURL       string = baseURL + "/timeseries"
-	fluctuationURL      string = baseURL + "/fluctuation"
-)
+// ErrProviderUnavailable is returned when a provider's circuit breaker is
+// open, or its retry budget was exhausted against repeated 429/5xx
+// responses.
+var ErrProviderUnavailable = errors.New("provider unavailable")
 
 // Exchange is returned by New() and allows access to the methods
 type Exchange struct {
 	Base          string
 	CacheEnabled  bool
 	isInitialized bool // is set to true if used via New
+	provider      Provider
+
+	// backpressure and subscribeBufferSize configure Subscribe; set via
+	// WithBackpressure and WithSubscribeBufferSize.
+	backpressure        BackpressureMode
+	subscribeBufferSize int
 }
 
 type query struct {
@@ -62,25 +55,17 @@ type query struct {
 	TimeFrame [2]string
 }
 
-// Client holds the one global HTTP client and the cache.
-//
-// You should set the AccessKey to your exchangerate.host access key.
-var Client = struct {
-	*http.Client
-	*gocache.Cache
-	AccessKey string
-}{
-	Client:    http.DefaultClient,
-	Cache:     gocache.New(cacheDuration(), 5*time.Minute),
-	AccessKey: os.Getenv("EXCHANGERATE_ACCESS_KEY"),
-}
-
-// New creates a new instance of Exchange
-func New(base string) *Exchange {
+// New creates a new instance of Exchange, backed by the exchangerate.host
+// API unless overridden with WithProvider.
+func New(base string, opts ...Option) *Exchange {
 	x := &Exchange{
 		Base:          base,
 		CacheEnabled:  true,
 		isInitialized: true,
+		provider:      &ExchangeRateHostProvider{CacheEnabled: true},
+	}
+	for _, opt := range opts {
+		opt(x)
 	}
 	return x
 }
@@ -97,20 +82,22 @@ func (exchange *Exchange) SetBase(base string) error {
 // SetCache enables and disable caching (caching last till midnight when the exchange rates are updated)
 func (exchange *Exchange) SetCache(enabled bool) {
 	exchange.CacheEnabled = enabled
+	if p, ok := exchange.provider.(*ExchangeRateHostProvider); ok {
+		p.CacheEnabled = enabled
+	}
 }
 
-// cacheDuration returns the cache duration - time till mignight.
-func cacheDuration() time.Duration {
-	now := time.Now().UTC()
-	midnight := now.AddDate(0, 0, 1).Truncate(24 * time.Hour)
-	return midnight.Sub(now)
-}
-
-// ValidateCode validates a single symbol code
+// ValidateCode validates a single symbol code: exactly 3 uppercase letters
+// (e.g. "USD", "EUR").
 func ValidateCode(code string) error {
 	if len(code) != 3 {
 		return ErrInvalidCode
 	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return ErrInvalidCode
+		}
+	}
 	return nil
 }
 
@@ -171,204 +158,17 @@ func ValidateTimeFrame(TimeFrame [2]string) error {
 	return nil
 }
 
-func (exchange *Exchange) get(url string, q query) (map[string]interface{}, error) {
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	processQuery(req, q)
-
-	cacheKey := req.URL.String()
-
-	if exchange.CacheEnabled {
-		if response, ok := Client.Cache.Get(cacheKey); ok == true {
-			return response.(map[string]interface{}), nil
-		}
-	}
-
-	resp, err := Client.Client.Do(req)
-
-	if err != nil {
-		return nil, err
-	}
-
-	var result map[string]interface{}
-
-	b, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-	if err = json.Unmarshal(b, &result); err != nil {
-		return nil, fmt.Errorf("unmarshal %q: %w", string(b), err)
-	}
-
-	success := result["success"]
-
-	if !success.(bool) {
-		return nil, fmt.Errorf("%q: %w", string(b), ErrInvalidAPIResponse)
-	}
-
-	if exchange.CacheEnabled {
-		Client.Cache.SetDefault(cacheKey, result)
-	}
-
-	return result, nil
-}
-
-func processQuery(req *http.Request, q query) error {
-	Q := req.URL.Query()
-
-	Q.Add("access_key", Client.AccessKey)
-
-	if q.Base != "" {
-		if err := ValidateCode(q.Base); err != nil {
-			return err
-		}
-		Q.Add("base", q.Base)
-	}
-
-	if q.From != "" {
-		if err := ValidateCode(q.From); err != nil {
-			return err
-		}
-		Q.Add("from", q.From)
-	}
-
-	if q.To != "" {
-		if err := ValidateCode(q.To); err != nil {
-			return err
-		}
-		Q.Add("to", q.To)
-	}
-
-	if q.Amount > 1 {
-		Q.Add("amount", strconv.Itoa(q.Amount))
-	}
-
-	if len(q.Symbols) != 0 {
-		Q.Add("symbols", strings.Join(q.Symbols, ","))
-	}
-
-	if q.Date != "" {
-		if err := ValidateDate(q.Date); err != nil {
-			return err
-		}
-		Q.Add("date", q.Date)
-	}
-
-	if q.TimeFrame != [2]string{} {
-		for i := 0; i < 1; i++ {
-			if err := ValidateDate(q.TimeFrame[i]); err != nil {
-				return err
-			}
-		}
-		if err := ValidateTimeFrame(q.TimeFrame); err != nil {
-			return err
-		}
-		Q.Add("start_date", string(q.TimeFrame[0]))
-		Q.Add("end_date", string(q.TimeFrame[1]))
-	}
-
-	req.URL.RawQuery = Q.Encode() // Encode and assign back to the original query.
-
-	return nil
-}
-
-func (exchange *Exchange) apiSymbols() (map[string]map[string]string, error) {
-	resp, err := exchange.get(symbolsURL, query{})
-	if err != nil {
-		return nil, err
-	}
-	result := make(map[string]map[string]string)
-	for code, data := range resp["symbols"].(map[string]interface{}) {
-		values := make(map[string]string)
-		for name, value := range data.(map[string]interface{}) {
-			values[name] = value.(string)
-		}
-		result[code] = values
-	}
-	return result, nil
-}
-
-func (exchange *Exchange) apiCryptocurrencies() (map[string]map[string]string, error) {
-	resp, err := exchange.get(cryptocurrenciesURL, query{})
-	if err != nil {
-		return nil, err
-	}
-	result := make(map[string]map[string]string)
-	for code, data := range resp["cryptocurrencies"].(map[string]interface{}) {
-		values := make(map[string]string)
-		for name, value := range data.(map[string]interface{}) {
-			values[name] = value.(string)
-		}
-		result[code] = values
-	}
-	return result, nil
-}
-
-func (exchange *Exchange) apiLatest(q query) (map[string]*big.Float, error) {
-	resp, err := exchange.get(latestURL, q)
-	if err != nil {
-		return nil, err
-	}
-	result := resp["rates"].(map[string]interface{})
-	rates := make(map[string]*big.Float, len(result))
-	for key := range result {
-		rates[key] = big.NewFloat(result[key].(float64))
-	}
-	return rates, nil
-}
-
-func (exchange *Exchange) apiConvert(q query) (*big.Float, error) {
-	resp, err := exchange.get(convertURL, q)
-	if err != nil {
-		return nil, err
-	}
-	result := resp["result"].(float64)
-	return big.NewFloat(result), nil
-}
-
-func (exchange *Exchange) apiHistorical(q query) (map[string]*big.Float, error) {
-	if err := ValidateDate(q.Date); err != nil {
-		return nil, err
-	}
-	url := historicalURL + q.Date
-	q.Date = ""
-	resp, err := exchange.get(url, q)
-	if err != nil {
-		return nil, err
-	}
-	result := resp["rates"].(map[string]interface{})
-	rates := make(map[string]*big.Float, len(result))
-	for key := range result {
-		rates[key] = big.NewFloat(result[key].(float64))
-	}
-	return rates, nil
-}
-
-func (exchange *Exchange) apiTimeseriesAndFuctuation(url string, q query) (map[string]map[string]*big.Float, error) {
-	resp, err := exchange.get(url, q)
-	if err != nil {
-		return nil, err
-	}
-	result := make(map[string]map[string]*big.Float)
-	for date, rates := range resp["rates"].(map[string]interface{}) {
-		ratemap := make(map[string]*big.Float)
-		for symbol, rate := range rates.(map[string]interface{}) {
-			frate := big.NewFloat(rate.(float64))
-			ratemap[symbol] = frate
-			result[date] = ratemap
-		}
-	}
-	return result, nil
-}
-
 // ForexCodes returns and array of supported forex/fiat currency codes
 func (exchange *Exchange) ForexCodes() ([]string, error) {
+	return exchange.ForexCodesContext(context.Background())
+}
+
+// ForexCodesContext is ForexCodes with ctx threaded down to the underlying
+// HTTP request, so callers can cancel it or attach a deadline.
+func (exchange *Exchange) ForexCodesContext(ctx context.Context) ([]string, error) {
 	var codes []string
 
-	result, err := exchange.apiSymbols()
+	result, err := exchange.provider.Symbols(ctx, query{})
 	if err != nil {
 		return nil, err
 	}
@@ -383,14 +183,36 @@ func (exchange *Exchange) ForexCodes() ([]string, error) {
 
 // ForexData returns a map of supported forex/fiat currencies data (code & description)
 func (exchange *Exchange) ForexData() (map[string]map[string]string, error) {
-	return exchange.apiSymbols()
+	return exchange.ForexDataContext(context.Background())
+}
+
+// ForexDataContext is ForexData with ctx threaded down to the underlying
+// HTTP request, so callers can cancel it or attach a deadline.
+func (exchange *Exchange) ForexDataContext(ctx context.Context) (map[string]map[string]string, error) {
+	return exchange.provider.Symbols(ctx, query{})
+}
+
+// cryptocurrencies returns the supported cryptocurrency codes and their
+// descriptions, if the active provider exposes any.
+func (exchange *Exchange) cryptocurrencies(ctx context.Context) (map[string]map[string]string, error) {
+	cp, ok := exchange.provider.(cryptoProvider)
+	if !ok {
+		return nil, ErrUnsupportedOperation
+	}
+	return cp.Cryptocurrencies(ctx)
 }
 
 // CryptoCodes returns and array of supported cryptocurrency codes
 func (exchange *Exchange) CryptoCodes() ([]string, error) {
+	return exchange.CryptoCodesContext(context.Background())
+}
+
+// CryptoCodesContext is CryptoCodes with ctx threaded down to the
+// underlying HTTP request, so callers can cancel it or attach a deadline.
+func (exchange *Exchange) CryptoCodesContext(ctx context.Context) ([]string, error) {
 	var codes []string
 
-	result, err := exchange.apiCryptocurrencies()
+	result, err := exchange.cryptocurrencies(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -405,23 +227,48 @@ func (exchange *Exchange) CryptoCodes() ([]string, error) {
 
 // CryptoData returns a map of supported cryptocurrencies data (name and symbol)
 func (exchange *Exchange) CryptoData() (map[string]map[string]string, error) {
-	return exchange.apiCryptocurrencies()
+	return exchange.CryptoDataContext(context.Background())
+}
+
+// CryptoDataContext is CryptoData with ctx threaded down to the underlying
+// HTTP request, so callers can cancel it or attach a deadline.
+func (exchange *Exchange) CryptoDataContext(ctx context.Context) (map[string]map[string]string, error) {
+	return exchange.cryptocurrencies(ctx)
 }
 
 // LatestRatesAll returns the latest exchange rates for all supportedcurrencies
 func (exchange *Exchange) LatestRatesAll() (map[string]*big.Float, error) {
-	return exchange.apiLatest(query{Base: exchange.Base})
+	return exchange.LatestRatesAllContext(context.Background())
+}
+
+// LatestRatesAllContext is LatestRatesAll with ctx threaded down to the
+// underlying HTTP request, so callers can cancel it or attach a deadline.
+func (exchange *Exchange) LatestRatesAllContext(ctx context.Context) (map[string]*big.Float, error) {
+	return exchange.provider.Latest(ctx, query{Base: exchange.Base})
 }
 
 // LatestRatesMultiple returns the latest exchange rates for multiple currencies
 func (exchange *Exchange) LatestRatesMultiple(symbols []string) (map[string]*big.Float, error) {
-	return exchange.apiLatest(query{Base: exchange.Base, Symbols: symbols})
+	return exchange.LatestRatesMultipleContext(context.Background(), symbols)
+}
 
+// LatestRatesMultipleContext is LatestRatesMultiple with ctx threaded down
+// to the underlying HTTP request, so callers can cancel it or attach a
+// deadline.
+func (exchange *Exchange) LatestRatesMultipleContext(ctx context.Context, symbols []string) (map[string]*big.Float, error) {
+	return exchange.provider.Latest(ctx, query{Base: exchange.Base, Symbols: symbols})
 }
 
 // LatestRatesSingle returns the latest exchange rates for a single currencies
 func (exchange *Exchange) LatestRatesSingle(symbol string) (*big.Float, error) {
-	resp, err := exchange.apiLatest(query{Base: exchange.Base, Symbols: []string{symbol}})
+	return exchange.LatestRatesSingleContext(context.Background(), symbol)
+}
+
+// LatestRatesSingleContext is LatestRatesSingle with ctx threaded down to
+// the underlying HTTP request, so callers can cancel it or attach a
+// deadline.
+func (exchange *Exchange) LatestRatesSingleContext(ctx context.Context, symbol string) (*big.Float, error) {
+	resp, err := exchange.provider.Latest(ctx, query{Base: exchange.Base, Symbols: []string{symbol}})
 	if err != nil {
 		return &big.Float{}, err
 	}
@@ -430,29 +277,61 @@ func (exchange *Exchange) LatestRatesSingle(symbol string) (*big.Float, error) {
 
 // ConvertTo converts the amount from the exchange.Base currency to the target currency
 func (exchange *Exchange) ConvertTo(target string, amount int) (*big.Float, error) {
-	return exchange.apiConvert(query{From: exchange.Base, To: target, Amount: amount})
+	return exchange.ConvertToContext(context.Background(), target, amount)
+}
+
+// ConvertToContext is ConvertTo with ctx threaded down to the underlying
+// HTTP request, so callers can cancel it or attach a deadline.
+func (exchange *Exchange) ConvertToContext(ctx context.Context, target string, amount int) (*big.Float, error) {
+	return exchange.provider.Convert(ctx, query{From: exchange.Base, To: target, Amount: amount})
 }
 
 // ConvertAt converts the amount from the exchange.Base currency to the target currency
 // at a selected historical date
 func (exchange *Exchange) ConvertAt(date string, target string, amount int) (*big.Float, error) {
-	return exchange.apiConvert(query{From: exchange.Base, To: target, Amount: amount, Date: date})
+	return exchange.ConvertAtContext(context.Background(), date, target, amount)
+}
+
+// ConvertAtContext is ConvertAt with ctx threaded down to the underlying
+// HTTP request, so callers can cancel it or attach a deadline.
+func (exchange *Exchange) ConvertAtContext(ctx context.Context, date string, target string, amount int) (*big.Float, error) {
+	return exchange.provider.Convert(ctx, query{From: exchange.Base, To: target, Amount: amount, Date: date})
 }
 
 // HistoricalRatesAll returns the historical exchange rates for all supported currencies
 func (exchange *Exchange) HistoricalRatesAll(date string) (map[string]*big.Float, error) {
-	return exchange.apiHistorical(query{Base: exchange.Base, Date: date})
+	return exchange.HistoricalRatesAllContext(context.Background(), date)
+}
+
+// HistoricalRatesAllContext is HistoricalRatesAll with ctx threaded down to
+// the underlying HTTP request, so callers can cancel it or attach a
+// deadline.
+func (exchange *Exchange) HistoricalRatesAllContext(ctx context.Context, date string) (map[string]*big.Float, error) {
+	return exchange.provider.Historical(ctx, query{Base: exchange.Base, Date: date})
 }
 
 // HistoricalRatesMultiple returns the historical exchange rates for multiple currencies
 func (exchange *Exchange) HistoricalRatesMultiple(date string, symbols []string) (map[string]*big.Float, error) {
-	return exchange.apiHistorical(query{Base: exchange.Base, Symbols: symbols, Date: date})
+	return exchange.HistoricalRatesMultipleContext(context.Background(), date, symbols)
+}
 
+// HistoricalRatesMultipleContext is HistoricalRatesMultiple with ctx
+// threaded down to the underlying HTTP request, so callers can cancel it
+// or attach a deadline.
+func (exchange *Exchange) HistoricalRatesMultipleContext(ctx context.Context, date string, symbols []string) (map[string]*big.Float, error) {
+	return exchange.provider.Historical(ctx, query{Base: exchange.Base, Symbols: symbols, Date: date})
 }
 
 // HistoricalRatesSingle returns the historical exchange rates for a single currency
 func (exchange *Exchange) HistoricalRatesSingle(date string, symbol string) (*big.Float, error) {
-	resp, err := exchange.apiHistorical(query{Base: exchange.Base, Symbols: []string{symbol}, Date: date})
+	return exchange.HistoricalRatesSingleContext(context.Background(), date, symbol)
+}
+
+// HistoricalRatesSingleContext is HistoricalRatesSingle with ctx threaded
+// down to the underlying HTTP request, so callers can cancel it or attach
+// a deadline.
+func (exchange *Exchange) HistoricalRatesSingleContext(ctx context.Context, date string, symbol string) (*big.Float, error) {
+	resp, err := exchange.provider.Historical(ctx, query{Base: exchange.Base, Symbols: []string{symbol}, Date: date})
 	if err != nil {
 		return &big.Float{}, err
 	}
@@ -461,36 +340,70 @@ func (exchange *Exchange) HistoricalRatesSingle(date string, symbol string) (*bi
 
 // TimeseriesAll returns the timeseries for all supported symbols
 func (exchange *Exchange) TimeseriesAll(start string, end string) (map[string]map[string]*big.Float, error) {
-	resp, err := exchange.apiTimeseriesAndFuctuation(timeseriesURL, query{TimeFrame: [2]string{start, end}})
-	return resp, err
+	return exchange.TimeseriesAllContext(context.Background(), start, end)
+}
+
+// TimeseriesAllContext is TimeseriesAll with ctx threaded down to the
+// underlying HTTP request, so callers can cancel it or attach a deadline.
+func (exchange *Exchange) TimeseriesAllContext(ctx context.Context, start string, end string) (map[string]map[string]*big.Float, error) {
+	return exchange.provider.Timeseries(ctx, query{TimeFrame: [2]string{start, end}})
 }
 
 // TimeseriesMultiple returns the timeseries for multiple symbols
 func (exchange *Exchange) TimeseriesMultiple(start string, end string, symbols []string) (map[string]map[string]*big.Float, error) {
-	resp, err := exchange.apiTimeseriesAndFuctuation(timeseriesURL, query{TimeFrame: [2]string{start, end}, Symbols: symbols})
-	return resp, err
+	return exchange.TimeseriesMultipleContext(context.Background(), start, end, symbols)
+}
+
+// TimeseriesMultipleContext is TimeseriesMultiple with ctx threaded down
+// to the underlying HTTP request, so callers can cancel it or attach a
+// deadline.
+func (exchange *Exchange) TimeseriesMultipleContext(ctx context.Context, start string, end string, symbols []string) (map[string]map[string]*big.Float, error) {
+	return exchange.provider.Timeseries(ctx, query{TimeFrame: [2]string{start, end}, Symbols: symbols})
 }
 
 // TimeseriesSingle returns the timeseries for a single symbol<
 func (exchange *Exchange) TimeseriesSingle(start string, end string, symbol string) (map[string]map[string]*big.Float, error) {
-	resp, err := exchange.apiTimeseriesAndFuctuation(timeseriesURL, query{TimeFrame: [2]string{start, end}, Symbols: []string{symbol}})
-	return resp, err
+	return exchange.TimeseriesSingleContext(context.Background(), start, end, symbol)
+}
+
+// TimeseriesSingleContext is TimeseriesSingle with ctx threaded down to the
+// underlying HTTP request, so callers can cancel it or attach a deadline.
+func (exchange *Exchange) TimeseriesSingleContext(ctx context.Context, start string, end string, symbol string) (map[string]map[string]*big.Float, error) {
+	return exchange.provider.Timeseries(ctx, query{TimeFrame: [2]string{start, end}, Symbols: []string{symbol}})
 }
 
 // FluctuationAll returns the fluctuation for all supported symbols
 func (exchange *Exchange) FluctuationAll(start string, end string) (map[string]map[string]*big.Float, error) {
-	resp, err := exchange.apiTimeseriesAndFuctuation(fluctuationURL, query{TimeFrame: [2]string{start, end}})
-	return resp, err
+	return exchange.FluctuationAllContext(context.Background(), start, end)
+}
+
+// FluctuationAllContext is FluctuationAll with ctx threaded down to the
+// underlying HTTP request, so callers can cancel it or attach a deadline.
+func (exchange *Exchange) FluctuationAllContext(ctx context.Context, start string, end string) (map[string]map[string]*big.Float, error) {
+	return exchange.provider.Fluctuation(ctx, query{TimeFrame: [2]string{start, end}})
 }
 
 // FluctuationMultiple returns the fluctuation for multiple symbols
 func (exchange *Exchange) FluctuationMultiple(start string, end string, symbols []string) (map[string]map[string]*big.Float, error) {
-	resp, err := exchange.apiTimeseriesAndFuctuation(fluctuationURL, query{TimeFrame: [2]string{start, end}, Symbols: symbols})
-	return resp, err
+	return exchange.FluctuationMultipleContext(context.Background(), start, end, symbols)
+}
+
+// FluctuationMultipleContext is FluctuationMultiple with ctx threaded down
+// to the underlying HTTP request, so callers can cancel it or attach a
+// deadline.
+func (exchange *Exchange) FluctuationMultipleContext(ctx context.Context, start string, end string, symbols []string) (map[string]map[string]*big.Float, error) {
+	return exchange.provider.Fluctuation(ctx, query{TimeFrame: [2]string{start, end}, Symbols: symbols})
 }
 
 // FluctuationSingle returns the fluctuation for a single symbol
 func (exchange *Exchange) FluctuationSingle(start string, end string, symbol string) (map[string]*big.Float, error) {
-	resp, err := exchange.apiTimeseriesAndFuctuation(fluctuationURL, query{TimeFrame: [2]string{start, end}, Symbols: []string{symbol}})
+	return exchange.FluctuationSingleContext(context.Background(), start, end, symbol)
+}
+
+// FluctuationSingleContext is FluctuationSingle with ctx threaded down to
+// the underlying HTTP request, so callers can cancel it or attach a
+// deadline.
+func (exchange *Exchange) FluctuationSingleContext(ctx context.Context, start string, end string, symbol string) (map[string]*big.Float, error) {
+	resp, err := exchange.provider.Fluctuation(ctx, query{TimeFrame: [2]string{start, end}, Symbols: []string{symbol}})
 	return resp[symbol], err
 }
@@ -0,0 +1,73 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"math/big"
+)
+
+// ErrUnsupportedOperation is returned by a Provider that does not implement
+// a given endpoint, e.g. the ECB feed has no /convert equivalent.
+var ErrUnsupportedOperation = errors.New("operation not supported by this provider")
+
+// Provider is implemented by an exchange rate data source. Exchange
+// delegates every rate lookup to its configured Provider, so callers can
+// swap sources, fall back on outages, or combine free and paid backends.
+// Implementations adapt their responses to the map[string]*big.Float shape
+// used throughout this package, report unsupported endpoints with
+// ErrUnsupportedOperation rather than silently approximating them, and
+// propagate ctx down to the underlying HTTP request so callers can cancel
+// or set a deadline.
+type Provider interface {
+	// Symbols returns the supported currency codes and their descriptions.
+	Symbols(ctx context.Context, q query) (map[string]map[string]string, error)
+	// Latest returns the most recent exchange rates for q.Base/q.Symbols.
+	Latest(ctx context.Context, q query) (map[string]*big.Float, error)
+	// Historical returns the exchange rates on q.Date.
+	Historical(ctx context.Context, q query) (map[string]*big.Float, error)
+	// Convert converts q.Amount from q.From to q.To.
+	Convert(ctx context.Context, q query) (*big.Float, error)
+	// Timeseries returns the daily rates over q.TimeFrame, keyed by date.
+	Timeseries(ctx context.Context, q query) (map[string]map[string]*big.Float, error)
+	// Fluctuation returns the rate change over q.TimeFrame, keyed by symbol.
+	Fluctuation(ctx context.Context, q query) (map[string]map[string]*big.Float, error)
+}
+
+// cacheableProvider is implemented by providers that support a pluggable
+// Cache backend and TTL policy, applied via WithCache.
+type cacheableProvider interface {
+	SetCachePolicy(*CachePolicy)
+}
+
+// WithCache overrides the default midnight-expiring in-memory cache with
+// the given backend, and caches historical/timeseries/fluctuation
+// responses forever since they describe days that have already closed.
+// It only affects providers that implement cacheableProvider (currently
+// ExchangeRateHostProvider), so apply it after WithProvider.
+func WithCache(c Cache) Option {
+	policy := &CachePolicy{Cache: c}
+	return func(exchange *Exchange) {
+		if cp, ok := exchange.provider.(cacheableProvider); ok {
+			cp.SetCachePolicy(policy)
+		}
+	}
+}
+
+// cryptoProvider is implemented by providers that also expose cryptocurrency
+// symbol listings. Not every Provider does (the ECB feed and Frankfurter are
+// fiat-only), so Exchange probes for it rather than requiring it.
+type cryptoProvider interface {
+	Cryptocurrencies(ctx context.Context) (map[string]map[string]string, error)
+}
+
+// Option configures an Exchange created by New.
+type Option func(*Exchange)
+
+// WithProvider overrides the default exchangerate.host backend with p,
+// letting callers swap rate sources, fall back on outages, or combine
+// free and paid backends.
+func WithProvider(p Provider) Option {
+	return func(exchange *Exchange) {
+		exchange.provider = p
+	}
+}
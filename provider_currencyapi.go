@@ -0,0 +1,234 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+)
+
+// CurrencyAPIProvider is a generic adapter for currencyapi.com-style JSON
+// backends: an API key passed as a query parameter, a /latest and
+// /historical endpoint keyed by "data", and per-currency {"code","value"}
+// objects instead of bare floats. Timeseries and Fluctuation are not part
+// of that API shape, so they're synthesized from repeated Historical
+// calls, one per day in the range.
+type CurrencyAPIProvider struct {
+	// BaseURL is the API root, e.g. "https://api.currencyapi.com/v3".
+	BaseURL string
+	// APIKey is sent as the "apikey" query parameter on every request.
+	APIKey string
+}
+
+// NewCurrencyAPIProvider returns a CurrencyAPIProvider for the given API
+// root and key.
+func NewCurrencyAPIProvider(baseURL, apiKey string) *CurrencyAPIProvider {
+	return &CurrencyAPIProvider{BaseURL: baseURL, APIKey: apiKey}
+}
+
+func (p *CurrencyAPIProvider) getJSON(ctx context.Context, path string, Q url.Values) (map[string]interface{}, error) {
+	if Q == nil {
+		Q = url.Values{}
+	}
+	Q.Set("apikey", p.APIKey)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", p.BaseURL+path+"?"+Q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %q: %w", resp.Status, string(b), ErrInvalidAPIResponse)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal %q: %w", string(b), err)
+	}
+	return result, nil
+}
+
+func currencyAPIRates(resp map[string]interface{}) (map[string]*big.Float, error) {
+	data, ok := resp["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%v: %w", resp, ErrInvalidAPIResponse)
+	}
+	rates := make(map[string]*big.Float, len(data))
+	for code, entry := range data {
+		fields, ok := entry.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s: %v: %w", code, entry, ErrInvalidAPIResponse)
+		}
+		value, ok := fields["value"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("%s: %v: %w", code, fields, ErrInvalidAPIResponse)
+		}
+		rates[code] = big.NewFloat(value)
+	}
+	return rates, nil
+}
+
+func currencyAPIQuery(q query) url.Values {
+	Q := url.Values{}
+	if q.Base != "" {
+		Q.Set("base_currency", q.Base)
+	}
+	if len(q.Symbols) != 0 {
+		Q["currencies"] = q.Symbols
+	}
+	return Q
+}
+
+// Symbols implements Provider.
+func (p *CurrencyAPIProvider) Symbols(ctx context.Context, q query) (map[string]map[string]string, error) {
+	resp, err := p.getJSON(ctx, "/currencies", currencyAPIQuery(q))
+	if err != nil {
+		return nil, err
+	}
+	data, ok := resp["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%v: %w", resp, ErrInvalidAPIResponse)
+	}
+	result := make(map[string]map[string]string, len(data))
+	for code, entry := range data {
+		values := make(map[string]string)
+		if fields, ok := entry.(map[string]interface{}); ok {
+			for name, value := range fields {
+				if s, ok := value.(string); ok {
+					values[name] = s
+				}
+			}
+		}
+		result[code] = values
+	}
+	return result, nil
+}
+
+// Latest implements Provider.
+func (p *CurrencyAPIProvider) Latest(ctx context.Context, q query) (map[string]*big.Float, error) {
+	resp, err := p.getJSON(ctx, "/latest", currencyAPIQuery(q))
+	if err != nil {
+		return nil, err
+	}
+	return currencyAPIRates(resp)
+}
+
+// Historical implements Provider.
+func (p *CurrencyAPIProvider) Historical(ctx context.Context, q query) (map[string]*big.Float, error) {
+	if err := ValidateDate(q.Date); err != nil {
+		return nil, err
+	}
+	Q := currencyAPIQuery(q)
+	Q.Set("date", q.Date)
+	resp, err := p.getJSON(ctx, "/historical", Q)
+	if err != nil {
+		return nil, err
+	}
+	return currencyAPIRates(resp)
+}
+
+// Convert implements Provider, computed locally since this API shape has
+// no dedicated /convert endpoint.
+func (p *CurrencyAPIProvider) Convert(ctx context.Context, q query) (*big.Float, error) {
+	rq := query{Base: q.From, Symbols: []string{q.To}, Date: q.Date}
+
+	var rates map[string]*big.Float
+	var err error
+	if q.Date != "" {
+		rates, err = p.Historical(ctx, rq)
+	} else {
+		rates, err = p.Latest(ctx, rq)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rate, ok := rates[q.To]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", q.To, ErrInvalidCode)
+	}
+
+	amount := q.Amount
+	if amount == 0 {
+		amount = 1
+	}
+	return new(big.Float).Mul(rate, big.NewFloat(float64(amount))), nil
+}
+
+// Timeseries implements Provider by issuing one Historical call per day in
+// q.TimeFrame, since this API shape has no native range endpoint.
+func (p *CurrencyAPIProvider) Timeseries(ctx context.Context, q query) (map[string]map[string]*big.Float, error) {
+	if err := ValidateTimeFrame(q.TimeFrame); err != nil {
+		return nil, err
+	}
+	from, err := parseDate(q.TimeFrame[0])
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseDate(q.TimeFrame[1])
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]map[string]*big.Float)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		rates, err := p.Historical(ctx, query{Base: q.Base, Symbols: q.Symbols, Date: date})
+		if err != nil {
+			return nil, err
+		}
+		result[date] = rates
+	}
+	return result, nil
+}
+
+// Fluctuation implements Provider by comparing the Historical rates at the
+// two ends of q.TimeFrame, since this API shape has no native fluctuation
+// endpoint.
+func (p *CurrencyAPIProvider) Fluctuation(ctx context.Context, q query) (map[string]map[string]*big.Float, error) {
+	if err := ValidateTimeFrame(q.TimeFrame); err != nil {
+		return nil, err
+	}
+
+	start, err := p.Historical(ctx, query{Base: q.Base, Symbols: q.Symbols, Date: q.TimeFrame[0]})
+	if err != nil {
+		return nil, err
+	}
+	end, err := p.Historical(ctx, query{Base: q.Base, Symbols: q.Symbols, Date: q.TimeFrame[1]})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]map[string]*big.Float, len(end))
+	for symbol, endRate := range end {
+		startRate, ok := start[symbol]
+		if !ok {
+			continue
+		}
+		change := new(big.Float).Sub(endRate, startRate)
+		changePct := new(big.Float)
+		if startRate.Sign() != 0 {
+			changePct = new(big.Float).Quo(change, startRate)
+			changePct.Mul(changePct, big.NewFloat(100))
+		}
+		result[symbol] = map[string]*big.Float{
+			"start_rate": startRate,
+			"end_rate":   endRate,
+			"change":     change,
+			"change_pct": changePct,
+		}
+	}
+	return result, nil
+}
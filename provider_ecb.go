@@ -0,0 +1,135 @@
+package exchange
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+)
+
+// ecbDailyFeedURL is the European Central Bank's daily reference rates
+// feed, published once a day around 16:00 CET, quoted against EUR.
+const ecbDailyFeedURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+// ECBProvider reads the European Central Bank's daily reference rate feed.
+// It only ever quotes EUR as the base and only ever reflects the latest
+// publication, so Historical, Convert, Timeseries and Fluctuation all
+// return ErrUnsupportedOperation.
+type ECBProvider struct {
+	// FeedURL overrides ecbDailyFeedURL, mainly for tests.
+	FeedURL string
+}
+
+// NewECBProvider returns an ECBProvider reading the public daily feed.
+func NewECBProvider() *ECBProvider {
+	return &ECBProvider{}
+}
+
+func (p *ECBProvider) feedURL() string {
+	if p.FeedURL != "" {
+		return p.FeedURL
+	}
+	return ecbDailyFeedURL
+}
+
+type ecbEnvelope struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Cube    struct {
+		Cube struct {
+			Time string `xml:"time,attr"`
+			Cube []struct {
+				Currency string `xml:"currency,attr"`
+				Rate     string `xml:"rate,attr"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+func (p *ECBProvider) fetch(ctx context.Context) (ecbEnvelope, error) {
+	var envelope ecbEnvelope
+
+	req, err := http.NewRequestWithContext(ctx, "GET", p.feedURL(), nil)
+	if err != nil {
+		return envelope, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return envelope, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return envelope, err
+	}
+
+	if err := xml.Unmarshal(b, &envelope); err != nil {
+		return envelope, fmt.Errorf("unmarshal %q: %w", string(b), err)
+	}
+	return envelope, nil
+}
+
+// Latest implements Provider. q.Base is ignored: the feed is always
+// quoted against EUR.
+func (p *ECBProvider) Latest(ctx context.Context, q query) (map[string]*big.Float, error) {
+	envelope, err := p.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(q.Symbols))
+	for _, symbol := range q.Symbols {
+		wanted[symbol] = true
+	}
+
+	rates := make(map[string]*big.Float, len(envelope.Cube.Cube.Cube))
+	for _, c := range envelope.Cube.Cube.Cube {
+		if len(wanted) != 0 && !wanted[c.Currency] {
+			continue
+		}
+		rate, _, err := big.ParseFloat(c.Rate, 10, 53, big.ToNearestEven)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q: %w", c.Currency, c.Rate, err)
+		}
+		rates[c.Currency] = rate
+	}
+	return rates, nil
+}
+
+// Symbols implements Provider, derived from the latest feed since the ECB
+// publishes no separate symbol-listing endpoint.
+func (p *ECBProvider) Symbols(ctx context.Context, q query) (map[string]map[string]string, error) {
+	rates, err := p.Latest(ctx, query{})
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]map[string]string, len(rates)+1)
+	result["EUR"] = map[string]string{"description": "Euro"}
+	for code := range rates {
+		result[code] = map[string]string{"description": code}
+	}
+	return result, nil
+}
+
+// Historical implements Provider. The ECB daily feed only carries the
+// latest publication, so this always returns ErrUnsupportedOperation.
+func (p *ECBProvider) Historical(ctx context.Context, q query) (map[string]*big.Float, error) {
+	return nil, ErrUnsupportedOperation
+}
+
+// Convert implements Provider. Not supported by this feed.
+func (p *ECBProvider) Convert(ctx context.Context, q query) (*big.Float, error) {
+	return nil, ErrUnsupportedOperation
+}
+
+// Timeseries implements Provider. Not supported by this feed.
+func (p *ECBProvider) Timeseries(ctx context.Context, q query) (map[string]map[string]*big.Float, error) {
+	return nil, ErrUnsupportedOperation
+}
+
+// Fluctuation implements Provider. Not supported by this feed.
+func (p *ECBProvider) Fluctuation(ctx context.Context, q query) (map[string]map[string]*big.Float, error) {
+	return nil, ErrUnsupportedOperation
+}
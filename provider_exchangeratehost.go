@@ -0,0 +1,291 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	gocache "github.com/patrickmn/go-cache"
+)
+
+const (
+	exchangeRateHostBaseURL           string = "https://api.exchangerate.host"
+	exchangeRateHostSymbolsURL        string = exchangeRateHostBaseURL + "/symbols"
+	exchangeRateHostCryptocurrencyURL string = exchangeRateHostBaseURL + "/cryptocurrencies"
+	exchangeRateHostLatestURL         string = exchangeRateHostBaseURL + "/latest"
+	exchangeRateHostConvertURL        string = exchangeRateHostBaseURL + "/convert"
+	exchangeRateHostHistoricalURL     string = exchangeRateHostBaseURL + "/"
+	exchangeRateHostTimeseriesURL     string = exchangeRateHostBaseURL + "/timeseries"
+	exchangeRateHostFluctuationURL    string = exchangeRateHostBaseURL + "/fluctuation"
+)
+
+// Client holds the one global HTTP client, cache, rate limiter, retry
+// policy and circuit breaker used by ExchangeRateHostProvider, the default
+// backend for this package.
+//
+// You should set the AccessKey to your exchangerate.host access key.
+var Client = struct {
+	*http.Client
+	*gocache.Cache
+	AccessKey string
+	Limiter   *RateLimiter
+	Retry     *RetryPolicy
+	Breaker   *CircuitBreaker
+}{
+	Client:    http.DefaultClient,
+	Cache:     gocache.New(cacheDuration(), 5*time.Minute),
+	AccessKey: os.Getenv("EXCHANGERATE_ACCESS_KEY"),
+	Limiter:   NewRateLimiter(5, 5),
+	Retry:     &RetryPolicy{},
+	Breaker:   &CircuitBreaker{},
+}
+
+// cacheDuration returns the cache duration - time till mignight.
+func cacheDuration() time.Duration {
+	now := time.Now().UTC()
+	midnight := now.AddDate(0, 0, 1).Truncate(24 * time.Hour)
+	return midnight.Sub(now)
+}
+
+// ExchangeRateHostProvider talks to https://api.exchangerate.host, the
+// original (and still default) backend for this package.
+type ExchangeRateHostProvider struct {
+	// CacheEnabled mirrors Exchange.CacheEnabled; Exchange.SetCache keeps
+	// the two in sync for the default provider.
+	CacheEnabled bool
+
+	// Policy, when set via WithCache, replaces the package-level
+	// Client.Cache with a pluggable backend and per-endpoint TTLs. Left
+	// nil, responses fall back to the original midnight-expiring
+	// Client.Cache for backward compatibility.
+	Policy *CachePolicy
+}
+
+// SetCachePolicy implements cacheableProvider.
+func (p *ExchangeRateHostProvider) SetCachePolicy(policy *CachePolicy) {
+	p.Policy = policy
+}
+
+// get fetches url, caching the decoded response under kind. end is the
+// last date the response describes (used by CachePolicy to decide
+// whether a historical/timeseries/fluctuation response may be cached
+// forever yet, or only until the day it describes has closed); pass "" if
+// kind doesn't need it (Symbols, Latest, Convert).
+func (p *ExchangeRateHostProvider) get(ctx context.Context, url string, q query, kind cacheKind, end string) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	processQuery(req, q)
+
+	cacheKey := req.URL.String()
+
+	if p.CacheEnabled {
+		if p.Policy != nil {
+			if response, ok := p.Policy.get(cacheKey); ok {
+				return response.(map[string]interface{}), nil
+			}
+		} else if response, ok := Client.Cache.Get(cacheKey); ok == true {
+			return response.(map[string]interface{}), nil
+		}
+	}
+
+	b, err := doWithResilience(ctx, Client.Client, req, Client.Limiter, Client.Retry, Client.Breaker)
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]interface{}
+	if err = json.Unmarshal(b, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal %q: %w", string(b), err)
+	}
+
+	success, _ := result["success"].(bool)
+	if !success {
+		return nil, fmt.Errorf("%q: %w", string(b), ErrInvalidAPIResponse)
+	}
+
+	if p.CacheEnabled {
+		if p.Policy != nil {
+			p.Policy.set(cacheKey, result, kind, end)
+		} else {
+			Client.Cache.SetDefault(cacheKey, result)
+		}
+	}
+
+	return result, nil
+}
+
+func processQuery(req *http.Request, q query) error {
+	Q := req.URL.Query()
+
+	Q.Add("access_key", Client.AccessKey)
+
+	if q.Base != "" {
+		if err := ValidateCode(q.Base); err != nil {
+			return err
+		}
+		Q.Add("base", q.Base)
+	}
+
+	if q.From != "" {
+		if err := ValidateCode(q.From); err != nil {
+			return err
+		}
+		Q.Add("from", q.From)
+	}
+
+	if q.To != "" {
+		if err := ValidateCode(q.To); err != nil {
+			return err
+		}
+		Q.Add("to", q.To)
+	}
+
+	if q.Amount > 1 {
+		Q.Add("amount", strconv.Itoa(q.Amount))
+	}
+
+	if len(q.Symbols) != 0 {
+		Q.Add("symbols", strings.Join(q.Symbols, ","))
+	}
+
+	if q.Date != "" {
+		if err := ValidateDate(q.Date); err != nil {
+			return err
+		}
+		Q.Add("date", q.Date)
+	}
+
+	if q.TimeFrame != [2]string{} {
+		for i := 0; i < 1; i++ {
+			if err := ValidateDate(q.TimeFrame[i]); err != nil {
+				return err
+			}
+		}
+		if err := ValidateTimeFrame(q.TimeFrame); err != nil {
+			return err
+		}
+		Q.Add("start_date", string(q.TimeFrame[0]))
+		Q.Add("end_date", string(q.TimeFrame[1]))
+	}
+
+	req.URL.RawQuery = Q.Encode() // Encode and assign back to the original query.
+
+	return nil
+}
+
+// Symbols implements Provider.
+func (p *ExchangeRateHostProvider) Symbols(ctx context.Context, q query) (map[string]map[string]string, error) {
+	resp, err := p.get(ctx, exchangeRateHostSymbolsURL, q, cacheKindSymbols, "")
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]map[string]string)
+	for code, data := range resp["symbols"].(map[string]interface{}) {
+		values := make(map[string]string)
+		for name, value := range data.(map[string]interface{}) {
+			values[name] = value.(string)
+		}
+		result[code] = values
+	}
+	return result, nil
+}
+
+// Cryptocurrencies returns the supported cryptocurrency codes and their
+// descriptions. It is not part of the Provider interface since not every
+// backend exposes one; Exchange probes for it via the cryptoProvider
+// interface.
+func (p *ExchangeRateHostProvider) Cryptocurrencies(ctx context.Context) (map[string]map[string]string, error) {
+	resp, err := p.get(ctx, exchangeRateHostCryptocurrencyURL, query{}, cacheKindSymbols, "")
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]map[string]string)
+	for code, data := range resp["cryptocurrencies"].(map[string]interface{}) {
+		values := make(map[string]string)
+		for name, value := range data.(map[string]interface{}) {
+			values[name] = value.(string)
+		}
+		result[code] = values
+	}
+	return result, nil
+}
+
+// Latest implements Provider.
+func (p *ExchangeRateHostProvider) Latest(ctx context.Context, q query) (map[string]*big.Float, error) {
+	resp, err := p.get(ctx, exchangeRateHostLatestURL, q, cacheKindLatest, "")
+	if err != nil {
+		return nil, err
+	}
+	result := resp["rates"].(map[string]interface{})
+	rates := make(map[string]*big.Float, len(result))
+	for key := range result {
+		rates[key] = big.NewFloat(result[key].(float64))
+	}
+	return rates, nil
+}
+
+// Convert implements Provider.
+func (p *ExchangeRateHostProvider) Convert(ctx context.Context, q query) (*big.Float, error) {
+	resp, err := p.get(ctx, exchangeRateHostConvertURL, q, cacheKindLatest, "")
+	if err != nil {
+		return nil, err
+	}
+	result := resp["result"].(float64)
+	return big.NewFloat(result), nil
+}
+
+// Historical implements Provider.
+func (p *ExchangeRateHostProvider) Historical(ctx context.Context, q query) (map[string]*big.Float, error) {
+	if err := ValidateDate(q.Date); err != nil {
+		return nil, err
+	}
+	url := exchangeRateHostHistoricalURL + q.Date
+	end := q.Date
+	q.Date = ""
+	resp, err := p.get(ctx, url, q, cacheKindHistorical, end)
+	if err != nil {
+		return nil, err
+	}
+	result := resp["rates"].(map[string]interface{})
+	rates := make(map[string]*big.Float, len(result))
+	for key := range result {
+		rates[key] = big.NewFloat(result[key].(float64))
+	}
+	return rates, nil
+}
+
+func (p *ExchangeRateHostProvider) timeseriesOrFluctuation(ctx context.Context, url string, q query, kind cacheKind) (map[string]map[string]*big.Float, error) {
+	resp, err := p.get(ctx, url, q, kind, q.TimeFrame[1])
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]map[string]*big.Float)
+	for date, rates := range resp["rates"].(map[string]interface{}) {
+		ratemap := make(map[string]*big.Float)
+		for symbol, rate := range rates.(map[string]interface{}) {
+			frate := big.NewFloat(rate.(float64))
+			ratemap[symbol] = frate
+			result[date] = ratemap
+		}
+	}
+	return result, nil
+}
+
+// Timeseries implements Provider.
+func (p *ExchangeRateHostProvider) Timeseries(ctx context.Context, q query) (map[string]map[string]*big.Float, error) {
+	return p.timeseriesOrFluctuation(ctx, exchangeRateHostTimeseriesURL, q, cacheKindTimeseries)
+}
+
+// Fluctuation implements Provider.
+func (p *ExchangeRateHostProvider) Fluctuation(ctx context.Context, q query) (map[string]map[string]*big.Float, error) {
+	return p.timeseriesOrFluctuation(ctx, exchangeRateHostFluctuationURL, q, cacheKindFluctuation)
+}
@@ -0,0 +1,205 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// FrankfurterProvider talks to https://api.frankfurter.app, a free,
+// key-less API backed by the European Central Bank's reference rates.
+// It has no /convert, /cryptocurrencies or /fluctuation equivalent, so
+// Convert is computed locally from Latest/Historical and Fluctuation
+// returns ErrUnsupportedOperation.
+type FrankfurterProvider struct {
+	// BaseURL overrides the default https://api.frankfurter.app, mainly
+	// for tests. Empty means use the default.
+	BaseURL string
+}
+
+// NewFrankfurterProvider returns a FrankfurterProvider using the public
+// api.frankfurter.app endpoint.
+func NewFrankfurterProvider() *FrankfurterProvider {
+	return &FrankfurterProvider{}
+}
+
+func (p *FrankfurterProvider) baseURL() string {
+	if p.BaseURL != "" {
+		return p.BaseURL
+	}
+	return "https://api.frankfurter.app"
+}
+
+func (p *FrankfurterProvider) getJSON(ctx context.Context, path string, Q url.Values) (map[string]interface{}, error) {
+	u := p.baseURL() + path
+	if len(Q) > 0 {
+		u += "?" + Q.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%q: %w", string(b), ErrInvalidCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %q: %w", resp.Status, string(b), ErrInvalidAPIResponse)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal %q: %w", string(b), err)
+	}
+	return result, nil
+}
+
+func ratesQuery(q query) url.Values {
+	Q := url.Values{}
+	if q.Base != "" {
+		Q.Set("from", q.Base)
+	} else if q.From != "" {
+		Q.Set("from", q.From)
+	}
+	if len(q.Symbols) != 0 {
+		Q.Set("to", strings.Join(q.Symbols, ","))
+	} else if q.To != "" {
+		Q.Set("to", q.To)
+	}
+	return Q
+}
+
+func ratesFromResponse(resp map[string]interface{}) (map[string]*big.Float, error) {
+	raw, ok := resp["rates"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%v: %w", resp, ErrInvalidAPIResponse)
+	}
+	rates := make(map[string]*big.Float, len(raw))
+	for key, value := range raw {
+		rates[key] = big.NewFloat(value.(float64))
+	}
+	return rates, nil
+}
+
+// Symbols implements Provider.
+func (p *FrankfurterProvider) Symbols(ctx context.Context, q query) (map[string]map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", p.baseURL()+"/currencies", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var names map[string]string
+	if err := json.Unmarshal(b, &names); err != nil {
+		return nil, fmt.Errorf("unmarshal %q: %w", string(b), err)
+	}
+
+	result := make(map[string]map[string]string, len(names))
+	for code, description := range names {
+		result[code] = map[string]string{"description": description}
+	}
+	return result, nil
+}
+
+// Latest implements Provider.
+func (p *FrankfurterProvider) Latest(ctx context.Context, q query) (map[string]*big.Float, error) {
+	resp, err := p.getJSON(ctx, "/latest", ratesQuery(q))
+	if err != nil {
+		return nil, err
+	}
+	return ratesFromResponse(resp)
+}
+
+// Historical implements Provider.
+func (p *FrankfurterProvider) Historical(ctx context.Context, q query) (map[string]*big.Float, error) {
+	if err := ValidateDate(q.Date); err != nil {
+		return nil, err
+	}
+	resp, err := p.getJSON(ctx, "/"+q.Date, ratesQuery(q))
+	if err != nil {
+		return nil, err
+	}
+	return ratesFromResponse(resp)
+}
+
+// Convert implements Provider. Frankfurter has no /convert endpoint, so
+// this fetches the rate and multiplies locally.
+func (p *FrankfurterProvider) Convert(ctx context.Context, q query) (*big.Float, error) {
+	rq := query{Base: q.From, Symbols: []string{q.To}, Date: q.Date}
+
+	var rates map[string]*big.Float
+	var err error
+	if q.Date != "" {
+		rates, err = p.Historical(ctx, rq)
+	} else {
+		rates, err = p.Latest(ctx, rq)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rate, ok := rates[q.To]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", q.To, ErrInvalidCode)
+	}
+
+	amount := q.Amount
+	if amount == 0 {
+		amount = 1
+	}
+	return new(big.Float).Mul(rate, big.NewFloat(float64(amount))), nil
+}
+
+// Timeseries implements Provider.
+func (p *FrankfurterProvider) Timeseries(ctx context.Context, q query) (map[string]map[string]*big.Float, error) {
+	if err := ValidateTimeFrame(q.TimeFrame); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/%s..%s", q.TimeFrame[0], q.TimeFrame[1])
+	resp, err := p.getJSON(ctx, path, ratesQuery(q))
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := resp["rates"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%v: %w", resp, ErrInvalidAPIResponse)
+	}
+	result := make(map[string]map[string]*big.Float, len(raw))
+	for date, rates := range raw {
+		ratemap := make(map[string]*big.Float)
+		for symbol, rate := range rates.(map[string]interface{}) {
+			ratemap[symbol] = big.NewFloat(rate.(float64))
+		}
+		result[date] = ratemap
+	}
+	return result, nil
+}
+
+// Fluctuation implements Provider. Frankfurter has no equivalent endpoint.
+func (p *FrankfurterProvider) Fluctuation(ctx context.Context, q query) (map[string]map[string]*big.Float, error) {
+	return nil, ErrUnsupportedOperation
+}
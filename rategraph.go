@@ -0,0 +1,371 @@
+package exchange
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"time"
+)
+
+// ErrNoRateGraphPath is returned when no chain of known quotes connects two
+// symbols in a RateGraph.
+var ErrNoRateGraphPath = errors.New("no rate path between symbols")
+
+// edge is a directed quote from one symbol to another: 1 From == Rate To.
+type edge struct {
+	to   string
+	rate *big.Float
+}
+
+// RateGraph is a directed graph of known pair quotes, fiat and
+// cryptocurrency symbols alike, used to derive a rate between two symbols
+// that have no direct quote by multiplying along a path of ones that do.
+type RateGraph struct {
+	edges map[string][]edge
+}
+
+// newRateGraph creates an empty RateGraph.
+func newRateGraph() *RateGraph {
+	return &RateGraph{edges: make(map[string][]edge)}
+}
+
+// addRate records a known quote: one unit of from equals rate units of to,
+// along with its implied inverse.
+func (g *RateGraph) addRate(from, to string, rate *big.Float) {
+	if from == to || rate.Sign() <= 0 {
+		return
+	}
+	g.edges[from] = append(g.edges[from], edge{to: to, rate: rate})
+	inverse := new(big.Float).Quo(big.NewFloat(1), rate)
+	g.edges[to] = append(g.edges[to], edge{to: from, rate: inverse})
+}
+
+// BuildRateGraph fetches the latest rates for symbols (one LatestRatesAll
+// call per symbol used as a base) at the given time and assembles them into
+// a RateGraph of direct quotes. at is currently only honored when it names
+// a past date (via HistoricalRatesAll); the zero time.Time means "now".
+func (exchange *Exchange) BuildRateGraph(symbols []string, at time.Time) (*RateGraph, error) {
+	g := newRateGraph()
+
+	for _, base := range symbols {
+		var rates map[string]*big.Float
+		var err error
+		if at.IsZero() {
+			rates, err = exchange.provider.Latest(context.Background(), query{Base: base, Symbols: symbols})
+		} else {
+			rates, err = exchange.provider.Historical(context.Background(), query{Base: base, Symbols: symbols, Date: at.Format("2006-01-02")})
+		}
+		if err != nil {
+			return nil, err
+		}
+		for quote, rate := range rates {
+			g.addRate(base, quote, rate)
+		}
+	}
+
+	return g, nil
+}
+
+// dijkstraItem is one entry in the shortest-path priority queue: the
+// cumulative log-weight to reach symbol via path.
+type dijkstraItem struct {
+	symbol string
+	weight float64
+	path   []string
+	rates  []*big.Float
+}
+
+type dijkstraQueue []*dijkstraItem
+
+func (q dijkstraQueue) Len() int            { return len(q) }
+func (q dijkstraQueue) Less(i, j int) bool  { return q[i].weight < q[j].weight }
+func (q dijkstraQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *dijkstraQueue) Push(x interface{}) { *q = append(*q, x.(*dijkstraItem)) }
+func (q *dijkstraQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[:n-1]
+	return item
+}
+
+// shortestPath returns the chain of rates to multiply along the cheapest
+// known path from source to target, measured as the lowest total
+// log(rate) weight. addRate's reciprocal inverse edges mean a rate below
+// 1 (negative log-weight) is present in virtually every graph, which
+// Dijkstra cannot handle correctly, so shortestPath only takes the
+// Dijkstra fast path when the graph is free of negative weights and
+// otherwise falls back to Bellman-Ford.
+func (g *RateGraph) shortestPath(source, target string) ([]*big.Float, error) {
+	if source == target {
+		return nil, nil
+	}
+	if g.hasNegativeWeight() {
+		return g.shortestPathBellmanFord(source, target)
+	}
+	return g.shortestPathDijkstra(source, target)
+}
+
+// hasNegativeWeight reports whether any edge has a rate below 1, i.e. a
+// negative log(rate) weight.
+func (g *RateGraph) hasNegativeWeight() bool {
+	for _, edges := range g.edges {
+		for _, e := range edges {
+			if mustFloat64(e.rate) < 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// shortestPathDijkstra runs Dijkstra over log(rate) edge weights; only
+// correct when the graph has no negative weights, see shortestPath.
+func (g *RateGraph) shortestPathDijkstra(source, target string) ([]*big.Float, error) {
+	best := map[string]float64{source: 0}
+	pq := &dijkstraQueue{{symbol: source, weight: 0}}
+	heap.Init(pq)
+
+	for pq.Len() > 0 {
+		item := heap.Pop(pq).(*dijkstraItem)
+		if item.symbol == target {
+			return item.rates, nil
+		}
+		if w, ok := best[item.symbol]; ok && item.weight > w {
+			continue
+		}
+		for _, e := range g.edges[item.symbol] {
+			w := item.weight + math.Log(mustFloat64(e.rate))
+			if existing, ok := best[e.to]; !ok || w < existing {
+				best[e.to] = w
+				heap.Push(pq, &dijkstraItem{
+					symbol: e.to,
+					weight: w,
+					path:   append(append([]string{}, item.path...), e.to),
+					rates:  append(append([]*big.Float{}, item.rates...), e.rate),
+				})
+			}
+		}
+	}
+
+	return nil, ErrNoRateGraphPath
+}
+
+// vertices returns every symbol that appears in the graph, either as an
+// edge source or as an edge target.
+func (g *RateGraph) vertices() []string {
+	seen := make(map[string]bool)
+	for from, edges := range g.edges {
+		seen[from] = true
+		for _, e := range edges {
+			seen[e.to] = true
+		}
+	}
+	vertices := make([]string, 0, len(seen))
+	for v := range seen {
+		vertices = append(vertices, v)
+	}
+	return vertices
+}
+
+// directRate returns the known quote from from to to, if one exists.
+func (g *RateGraph) directRate(from, to string) (*big.Float, error) {
+	for _, e := range g.edges[from] {
+		if e.to == to {
+			return e.rate, nil
+		}
+	}
+	return nil, ErrNoRateGraphPath
+}
+
+// shortestPathBellmanFord runs Bellman-Ford over log(rate) edge weights,
+// which (unlike Dijkstra) tolerates the negative weights addRate's
+// reciprocal inverse edges produce, and returns the chain of rates to
+// multiply along the cheapest source->target path.
+func (g *RateGraph) shortestPathBellmanFord(source, target string) ([]*big.Float, error) {
+	vertices := g.vertices()
+
+	dist := make(map[string]float64, len(vertices))
+	pred := make(map[string]string, len(vertices))
+	for _, v := range vertices {
+		dist[v] = math.Inf(1)
+	}
+	dist[source] = 0
+
+	for i := 0; i < len(vertices)-1; i++ {
+		changed := false
+		for u, edges := range g.edges {
+			if math.IsInf(dist[u], 1) {
+				continue
+			}
+			for _, e := range edges {
+				w := dist[u] + math.Log(mustFloat64(e.rate))
+				if w < dist[e.to]-1e-12 {
+					dist[e.to] = w
+					pred[e.to] = u
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	if d, ok := dist[target]; !ok || math.IsInf(d, 1) {
+		return nil, ErrNoRateGraphPath
+	}
+
+	path := []string{target}
+	for node := target; node != source; {
+		prev, ok := pred[node]
+		if !ok {
+			return nil, ErrNoRateGraphPath
+		}
+		path = append(path, prev)
+		node = prev
+	}
+	reverse(path)
+
+	rates := make([]*big.Float, 0, len(path)-1)
+	for i := 0; i < len(path)-1; i++ {
+		rate, err := g.directRate(path[i], path[i+1])
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
+
+func mustFloat64(f *big.Float) float64 {
+	v, _ := f.Float64()
+	return v
+}
+
+// CrossRate returns the A->B rate, composing it via the shortest chain of
+// known quotes (Dijkstra on log(rate) edge weights, or Bellman-Ford when
+// the graph has negative weights) when no direct quote exists between
+// from and to. To preserve big.Float precision, the chain's original
+// rates are multiplied directly rather than round-tripped through
+// exp(sum(log(...))).
+func (exchange *Exchange) CrossRate(from, to string, at time.Time) (*big.Float, error) {
+	g, err := exchange.BuildRateGraph([]string{from, to}, at)
+	if err != nil {
+		return nil, err
+	}
+	return g.CrossRate(from, to)
+}
+
+// CrossRate returns the A->B rate within an already-built RateGraph,
+// composing it via the shortest chain of known quotes when no direct quote
+// exists.
+func (g *RateGraph) CrossRate(from, to string) (*big.Float, error) {
+	rates, err := g.shortestPath(from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(rates) == 0 {
+		return big.NewFloat(1), nil
+	}
+	result := new(big.Float).Copy(rates[0])
+	for _, rate := range rates[1:] {
+		result.Mul(result, rate)
+	}
+	return result, nil
+}
+
+// Arbitrages reports cycles whose compounded rate is greater than 1, i.e.
+// converting around the loop yields more than you started with. It finds
+// these by running Bellman-Ford relaxation from every symbol in the graph
+// to locate a negative-weight cycle (log(rate) summing to less than zero,
+// i.e. a compounded rate below 1), then reports the cycle in the opposite
+// order: addRate always inserts a reciprocal inverse edge alongside every
+// quote, so walking the located cycle backwards is itself a valid path
+// through the graph, and it's the direction whose compounded rate is
+// above 1.
+func (g *RateGraph) Arbitrages() [][]string {
+	symbols := make([]string, 0, len(g.edges))
+	for s := range g.edges {
+		symbols = append(symbols, s)
+	}
+
+	var cycles [][]string
+	seen := make(map[string]bool)
+
+	for _, source := range symbols {
+		dist := make(map[string]float64, len(symbols))
+		pred := make(map[string]string, len(symbols))
+		for _, s := range symbols {
+			dist[s] = math.Inf(1)
+		}
+		dist[source] = 0
+
+		var last string
+		for i := 0; i < len(symbols); i++ {
+			last = ""
+			for u, edges := range g.edges {
+				if math.IsInf(dist[u], 1) {
+					continue
+				}
+				for _, e := range edges {
+					w := dist[u] + math.Log(mustFloat64(e.rate))
+					if w < dist[e.to]-1e-12 {
+						dist[e.to] = w
+						pred[e.to] = u
+						last = e.to
+					}
+				}
+			}
+		}
+
+		if last == "" {
+			continue
+		}
+
+		cycleNode := last
+		for i := 0; i < len(symbols); i++ {
+			cycleNode = pred[cycleNode]
+		}
+
+		// cycle walks pred backwards from cycleNode, i.e. against the
+		// negative-weight (sub-1 compounded rate) edges Bellman-Ford
+		// relaxed; reported as-is, that's the profitable direction.
+		cycle := []string{cycleNode}
+		for node := pred[cycleNode]; node != cycleNode; node = pred[node] {
+			cycle = append(cycle, node)
+		}
+		cycle = append(cycle, cycleNode)
+
+		key := cycleKey(cycle)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		cycles = append(cycles, cycle)
+	}
+
+	return cycles
+}
+
+func cycleKey(cycle []string) string {
+	min := 0
+	for i, s := range cycle {
+		if s < cycle[min] {
+			min = i
+		}
+	}
+	key := ""
+	for i := range cycle {
+		key += cycle[(min+i)%len(cycle)] + ">"
+	}
+	return key
+}
+
+func reverse(s []string) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
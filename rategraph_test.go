@@ -0,0 +1,100 @@
+package exchange
+
+import (
+	"math/big"
+	"testing"
+)
+
+func floatEquals(t *testing.T, got, want *big.Float, tolerance float64) {
+	t.Helper()
+	g, _ := got.Float64()
+	w, _ := want.Float64()
+	if diff := g - w; diff < -tolerance || diff > tolerance {
+		t.Errorf("got %v, want %v", g, w)
+	}
+}
+
+func TestRateGraphCrossRateDirect(t *testing.T) {
+	g := newRateGraph()
+	g.addRate("USD", "EUR", big.NewFloat(0.9))
+
+	rate, err := g.CrossRate("USD", "EUR")
+	if err != nil {
+		t.Fatalf("CrossRate: %v", err)
+	}
+	floatEquals(t, rate, big.NewFloat(0.9), 1e-9)
+}
+
+func TestRateGraphCrossRateViaIntermediate(t *testing.T) {
+	g := newRateGraph()
+	g.addRate("USD", "EUR", big.NewFloat(0.9))
+	g.addRate("EUR", "JPY", big.NewFloat(160))
+
+	rate, err := g.CrossRate("USD", "JPY")
+	if err != nil {
+		t.Fatalf("CrossRate: %v", err)
+	}
+	floatEquals(t, rate, big.NewFloat(0.9*160), 1e-6)
+}
+
+func TestRateGraphCrossRateUsesBellmanFordOnNegativeWeights(t *testing.T) {
+	g := newRateGraph()
+	// addRate always inserts the reciprocal inverse edge (rate < 1, a
+	// negative log-weight), so this graph always needs the Bellman-Ford
+	// fallback rather than plain Dijkstra.
+	g.addRate("USD", "EUR", big.NewFloat(0.9))
+	g.addRate("EUR", "JPY", big.NewFloat(160))
+
+	if !g.hasNegativeWeight() {
+		t.Fatal("expected reciprocal inverse edges to produce a negative weight")
+	}
+
+	rate, err := g.CrossRate("JPY", "USD")
+	if err != nil {
+		t.Fatalf("CrossRate: %v", err)
+	}
+	want := 1 / (0.9 * 160)
+	floatEquals(t, rate, big.NewFloat(want), 1e-9)
+}
+
+func TestRateGraphCrossRateNoPath(t *testing.T) {
+	g := newRateGraph()
+	g.addRate("USD", "EUR", big.NewFloat(0.9))
+
+	if _, err := g.CrossRate("USD", "GBP"); err != ErrNoRateGraphPath {
+		t.Errorf("err = %v, want ErrNoRateGraphPath", err)
+	}
+}
+
+func TestRateGraphArbitragesReportsProfitableDirection(t *testing.T) {
+	g := newRateGraph()
+	// A->B->C->A with a product noticeably above 1 is a profitable loop;
+	// addRate's inverse edges on their own (reciprocals, product == 1)
+	// must never be reported.
+	g.addRate("A", "B", big.NewFloat(2))
+	g.addRate("B", "C", big.NewFloat(2))
+	g.addRate("C", "A", big.NewFloat(2))
+
+	cycles := g.Arbitrages()
+	if len(cycles) == 0 {
+		t.Fatal("expected at least one arbitrage cycle")
+	}
+
+	for _, cycle := range cycles {
+		if len(cycle) < 2 {
+			t.Fatalf("cycle too short: %v", cycle)
+		}
+		product := big.NewFloat(1)
+		for i := 0; i < len(cycle)-1; i++ {
+			rate, err := g.directRate(cycle[i], cycle[i+1])
+			if err != nil {
+				t.Fatalf("directRate(%s, %s): %v", cycle[i], cycle[i+1], err)
+			}
+			product.Mul(product, rate)
+		}
+		p, _ := product.Float64()
+		if p <= 1 {
+			t.Errorf("cycle %v compounds to %v, want > 1 (the profitable direction)", cycle, p)
+		}
+	}
+}
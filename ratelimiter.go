@@ -0,0 +1,93 @@
+package exchange
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a simple token-bucket limiter: it holds Burst tokens and
+// refills at RPS tokens per second, blocking Wait callers until one is
+// available.
+type RateLimiter struct {
+	// RPS is the sustained requests-per-second rate. Defaults to 5 if zero.
+	RPS float64
+	// Burst is the maximum number of requests allowed to proceed without
+	// waiting for a refill. Defaults to RPS (rounded up, min 1) if zero.
+	Burst int
+
+	mu       sync.Mutex
+	tokens   float64
+	lastFill time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing rps requests per second
+// with the given burst size.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	return &RateLimiter{RPS: rps, Burst: burst}
+}
+
+func (l *RateLimiter) rps() float64 {
+	if l.RPS <= 0 {
+		return 5
+	}
+	return l.RPS
+}
+
+func (l *RateLimiter) burst() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	b := int(l.rps())
+	if b < 1 {
+		b = 1
+	}
+	return b
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	for {
+		d := l.reserve()
+		if d <= 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(d):
+		}
+	}
+}
+
+// reserve refills the bucket for elapsed time and, if a token is
+// available, consumes it and returns 0. Otherwise it returns how long the
+// caller must wait for the next token.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if l.lastFill.IsZero() {
+		l.tokens = float64(l.burst())
+		l.lastFill = now
+	} else {
+		elapsed := now.Sub(l.lastFill).Seconds()
+		l.tokens += elapsed * l.rps()
+		if max := float64(l.burst()); l.tokens > max {
+			l.tokens = max
+		}
+		l.lastFill = now
+	}
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	missing := 1 - l.tokens
+	return time.Duration(missing / l.rps() * float64(time.Second))
+}
@@ -0,0 +1,151 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy retries a request on 429 and 5xx responses (and on
+// transport errors) with exponential backoff, honoring a Retry-After
+// header when the server sends one.
+type RetryPolicy struct {
+	// MaxRetries is how many additional attempts to make after the first.
+	// Defaults to 3 if zero; set to -1 to disable retrying entirely.
+	MaxRetries int
+	// BaseDelay is the backoff for the first retry, doubling each
+	// subsequent attempt. Defaults to 500ms if zero.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff. Defaults to 10s if zero.
+	MaxDelay time.Duration
+}
+
+func (r *RetryPolicy) maxRetries() int {
+	if r == nil {
+		return 3
+	}
+	if r.MaxRetries == 0 {
+		return 3
+	}
+	if r.MaxRetries < 0 {
+		return 0
+	}
+	return r.MaxRetries
+}
+
+func (r *RetryPolicy) backoff(attempt int) time.Duration {
+	base := 500 * time.Millisecond
+	max := 10 * time.Second
+	if r != nil {
+		if r.BaseDelay > 0 {
+			base = r.BaseDelay
+		}
+		if r.MaxDelay > 0 {
+			max = r.MaxDelay
+		}
+	}
+	delay := time.Duration(float64(base) * math.Pow(2, float64(attempt)))
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
+
+func retryAfterDelay(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return time.Until(at), true
+	}
+	return 0, false
+}
+
+func isRetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+func newHTTPStatusError(code int, body []byte) error {
+	return fmt.Errorf("%d %s: %q: %w", code, http.StatusText(code), string(body), ErrProviderUnavailable)
+}
+
+// sleep waits for delay, returning early with ctx.Err() if ctx is done
+// first, so a cancelled or expired ctx can interrupt a pending retry.
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// doWithResilience runs req through limiter (if any), the circuit breaker,
+// and retry with backoff, returning the final response body on success.
+// It never returns a *http.Response to avoid leaking its body across
+// retries; callers get the already-drained bytes instead.
+func doWithResilience(ctx context.Context, client *http.Client, req *http.Request, limiter *RateLimiter, retry *RetryPolicy, breaker *CircuitBreaker) ([]byte, error) {
+	if !breaker.Allow() {
+		return nil, ErrProviderUnavailable
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= retry.maxRetries(); attempt++ {
+		if limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := client.Do(req.Clone(ctx))
+		if err != nil {
+			breaker.RecordFailure()
+			lastErr = err
+			if attempt < retry.maxRetries() {
+				if sleepErr := sleep(ctx, retry.backoff(attempt)); sleepErr != nil {
+					return nil, sleepErr
+				}
+				continue
+			}
+			return nil, err
+		}
+
+		b, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			breaker.RecordFailure()
+			return nil, readErr
+		}
+
+		if isRetryableStatus(resp.StatusCode) {
+			breaker.RecordFailure()
+			lastErr = newHTTPStatusError(resp.StatusCode, b)
+			if attempt < retry.maxRetries() {
+				delay := retry.backoff(attempt)
+				if after, ok := retryAfterDelay(resp); ok && after > delay {
+					delay = after
+				}
+				if sleepErr := sleep(ctx, delay); sleepErr != nil {
+					return nil, sleepErr
+				}
+				continue
+			}
+			return nil, lastErr
+		}
+
+		breaker.RecordSuccess()
+		return b, nil
+	}
+
+	return nil, lastErr
+}
@@ -0,0 +1,126 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDoWithResilienceRetriesOn5xxThenSucceeds(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	retry := &RetryPolicy{BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	breaker := &CircuitBreaker{}
+
+	b, err := doWithResilience(context.Background(), http.DefaultClient, req, nil, retry, breaker)
+	if err != nil {
+		t.Fatalf("doWithResilience: %v", err)
+	}
+	if string(b) != "ok" {
+		t.Errorf("body = %q, want %q", b, "ok")
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+}
+
+func TestDoWithResilienceExhaustsRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	retry := &RetryPolicy{MaxRetries: 1, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	breaker := &CircuitBreaker{Threshold: 100}
+
+	_, err = doWithResilience(context.Background(), http.DefaultClient, req, nil, retry, breaker)
+	if !errors.Is(err, ErrProviderUnavailable) {
+		t.Errorf("err = %v, want wrapping ErrProviderUnavailable", err)
+	}
+}
+
+func TestDoWithResilienceHonorsContextCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	retry := &RetryPolicy{MaxRetries: 5, BaseDelay: time.Hour, MaxDelay: time.Hour}
+	breaker := &CircuitBreaker{Threshold: 100}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = doWithResilience(ctx, http.DefaultClient, req, nil, retry, breaker)
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed > time.Second {
+		t.Errorf("doWithResilience took %s, want it to return promptly once ctx expired", elapsed)
+	}
+}
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	b := &CircuitBreaker{Threshold: 2, Cooldown: time.Hour}
+
+	if !b.Allow() {
+		t.Fatal("breaker should start closed")
+	}
+	b.RecordFailure()
+	if !b.Allow() {
+		t.Fatal("breaker should stay closed before threshold")
+	}
+	b.RecordFailure()
+	if b.Allow() {
+		t.Fatal("breaker should open once threshold consecutive failures are recorded")
+	}
+	b.RecordSuccess()
+	if !b.Allow() {
+		t.Fatal("breaker should close again after a recorded success")
+	}
+}
+
+func TestRetryPolicyBackoffDoublesAndCaps(t *testing.T) {
+	r := &RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 30 * time.Millisecond}
+
+	if d := r.backoff(0); d != 10*time.Millisecond {
+		t.Errorf("backoff(0) = %s, want 10ms", d)
+	}
+	if d := r.backoff(1); d != 20*time.Millisecond {
+		t.Errorf("backoff(1) = %s, want 20ms", d)
+	}
+	if d := r.backoff(2); d != 30*time.Millisecond {
+		t.Errorf("backoff(2) = %s, want 30ms (capped)", d)
+	}
+}
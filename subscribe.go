@@ -0,0 +1,151 @@
+package exchange
+
+import (
+	"context"
+	"math/big"
+	"time"
+)
+
+// BackpressureMode controls what Subscribe does when a consumer isn't
+// draining its channel fast enough.
+type BackpressureMode int
+
+const (
+	// BackpressureBlock makes Subscribe's poll loop block until the
+	// consumer reads, or until ctx is done. This is the default: it never
+	// loses an update, but a slow consumer delays the next poll.
+	BackpressureBlock BackpressureMode = iota
+	// BackpressureDropOldest makes Subscribe discard the oldest buffered
+	// update to make room for the newest one instead of blocking.
+	BackpressureDropOldest
+)
+
+// defaultSubscribeBufferSize is how many RateUpdates Subscribe buffers
+// before backpressure kicks in.
+const defaultSubscribeBufferSize = 16
+
+// RateUpdate is one changed quote emitted by Subscribe.
+type RateUpdate struct {
+	Base          string
+	Quote         string
+	Rate          *big.Float
+	PrevRate      *big.Float
+	PercentChange float64
+	At            time.Time
+}
+
+// WithBackpressure sets how Subscribe behaves when a consumer falls
+// behind. The default is BackpressureBlock.
+func WithBackpressure(mode BackpressureMode) Option {
+	return func(exchange *Exchange) {
+		exchange.backpressure = mode
+	}
+}
+
+// WithSubscribeBufferSize sets the channel buffer Subscribe allocates.
+// Defaults to 16 if unset or non-positive.
+func WithSubscribeBufferSize(n int) Option {
+	return func(exchange *Exchange) {
+		exchange.subscribeBufferSize = n
+	}
+}
+
+func (exchange *Exchange) subscribeBuffer() int {
+	if exchange.subscribeBufferSize > 0 {
+		return exchange.subscribeBufferSize
+	}
+	return defaultSubscribeBufferSize
+}
+
+// Subscribe polls LatestRatesMultiple for symbols every interval and emits
+// a RateUpdate for each pair whose rate changed since the previous poll.
+// The first poll only establishes the baseline; it emits nothing.
+//
+// A failed poll (rate limited, provider down, circuit breaker open, ...)
+// is skipped rather than treated as fatal, so the subscription survives
+// transient errors and automatically resumes polling on the next tick.
+// Subscribe only stops, closing the returned channel, when ctx is done.
+func (exchange *Exchange) Subscribe(ctx context.Context, symbols []string, interval time.Duration) (<-chan RateUpdate, error) {
+	if err := ValidateSymbols(symbols); err != nil {
+		return nil, err
+	}
+
+	ch := make(chan RateUpdate, exchange.subscribeBuffer())
+	go exchange.subscribeLoop(ctx, symbols, interval, ch)
+	return ch, nil
+}
+
+func (exchange *Exchange) subscribeLoop(ctx context.Context, symbols []string, interval time.Duration, ch chan RateUpdate) {
+	defer close(ch)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var prev map[string]*big.Float
+	poll := func() {
+		rates, err := exchange.LatestRatesMultipleContext(ctx, symbols)
+		if err != nil {
+			return
+		}
+		now := time.Now()
+		if prev != nil {
+			for quote, rate := range rates {
+				prevRate, ok := prev[quote]
+				if !ok || prevRate.Cmp(rate) == 0 {
+					continue
+				}
+				exchange.emit(ctx, ch, RateUpdate{
+					Base:          exchange.Base,
+					Quote:         quote,
+					Rate:          rate,
+					PrevRate:      prevRate,
+					PercentChange: percentChange(prevRate, rate),
+					At:            now,
+				})
+			}
+		}
+		prev = rates
+	}
+
+	poll()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			poll()
+		}
+	}
+}
+
+func (exchange *Exchange) emit(ctx context.Context, ch chan RateUpdate, update RateUpdate) {
+	if exchange.backpressure != BackpressureDropOldest {
+		select {
+		case ch <- update:
+		case <-ctx.Done():
+		}
+		return
+	}
+
+	select {
+	case ch <- update:
+	default:
+		select {
+		case <-ch:
+		default:
+		}
+		select {
+		case ch <- update:
+		default:
+		}
+	}
+}
+
+func percentChange(prev, cur *big.Float) float64 {
+	if prev.Sign() == 0 {
+		return 0
+	}
+	ratio := new(big.Float).Quo(new(big.Float).Sub(cur, prev), prev)
+	pct, _ := ratio.Float64()
+	return pct * 100
+}
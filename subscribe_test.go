@@ -0,0 +1,126 @@
+package exchange
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubLatestProvider implements Provider with a scripted sequence of
+// Latest responses, one per call, repeating the last one once exhausted.
+type stubLatestProvider struct {
+	mu    sync.Mutex
+	ticks []map[string]*big.Float
+	call  int
+}
+
+func (p *stubLatestProvider) next() map[string]*big.Float {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.ticks) == 0 {
+		return nil
+	}
+	i := p.call
+	if i >= len(p.ticks) {
+		i = len(p.ticks) - 1
+	}
+	p.call++
+	return p.ticks[i]
+}
+
+func (p *stubLatestProvider) Symbols(ctx context.Context, q query) (map[string]map[string]string, error) {
+	return nil, ErrUnsupportedOperation
+}
+func (p *stubLatestProvider) Latest(ctx context.Context, q query) (map[string]*big.Float, error) {
+	return p.next(), nil
+}
+func (p *stubLatestProvider) Historical(ctx context.Context, q query) (map[string]*big.Float, error) {
+	return nil, ErrUnsupportedOperation
+}
+func (p *stubLatestProvider) Convert(ctx context.Context, q query) (*big.Float, error) {
+	return nil, ErrUnsupportedOperation
+}
+func (p *stubLatestProvider) Timeseries(ctx context.Context, q query) (map[string]map[string]*big.Float, error) {
+	return nil, ErrUnsupportedOperation
+}
+func (p *stubLatestProvider) Fluctuation(ctx context.Context, q query) (map[string]map[string]*big.Float, error) {
+	return nil, ErrUnsupportedOperation
+}
+
+func TestSubscribeEmitsOnlyOnChange(t *testing.T) {
+	stub := &stubLatestProvider{ticks: []map[string]*big.Float{
+		{"EUR": big.NewFloat(0.9)},
+		{"EUR": big.NewFloat(0.9)}, // unchanged: no update expected
+		{"EUR": big.NewFloat(0.95)},
+	}}
+	exchange := New("USD", WithProvider(stub))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := exchange.Subscribe(ctx, []string{"EUR"}, time.Millisecond)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	select {
+	case update := <-ch:
+		if update.Quote != "EUR" {
+			t.Fatalf("Quote = %q, want EUR", update.Quote)
+		}
+		got, _ := update.Rate.Float64()
+		if got != 0.95 {
+			t.Errorf("Rate = %v, want 0.95", got)
+		}
+		prev, _ := update.PrevRate.Float64()
+		if prev != 0.9 {
+			t.Errorf("PrevRate = %v, want 0.9", prev)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a RateUpdate")
+	}
+
+	cancel()
+	select {
+	case _, ok := <-ch:
+		if ok {
+			// a second unrelated update is fine; just drain until closed.
+			for range ch {
+			}
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel was not closed after ctx cancellation")
+	}
+}
+
+func TestSubscribeInvalidSymbol(t *testing.T) {
+	exchange := New("USD", WithProvider(&stubLatestProvider{}))
+	if _, err := exchange.Subscribe(context.Background(), []string{"xyz"}, time.Second); err == nil {
+		t.Fatal("expected an error for a lowercase symbol")
+	}
+	if _, err := exchange.Subscribe(context.Background(), []string{"TOOLONG"}, time.Second); err == nil {
+		t.Fatal("expected an error for a symbol of the wrong length")
+	}
+}
+
+func TestEmitDropOldestNeverBlocks(t *testing.T) {
+	exchange := New("USD", WithProvider(&stubLatestProvider{}), WithBackpressure(BackpressureDropOldest), WithSubscribeBufferSize(1))
+
+	ch := make(chan RateUpdate, exchange.subscribeBuffer())
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		done := make(chan struct{})
+		go func() {
+			exchange.emit(ctx, ch, RateUpdate{Quote: "EUR"})
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("emit blocked under BackpressureDropOldest")
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// ErrEmptyTimeseries is returned when NewTimeseries is given no data points
+// to work with.
+var ErrEmptyTimeseries = errors.New("timeseries has no data points")
+
+// Timeseries is TimeseriesAll/Multiple/Single's raw
+// map[string]map[string]*big.Float reshaped into sorted dates and one
+// slice per symbol, so analytics can walk it with a single O(n) or
+// O(n·window) pass instead of re-sorting map keys each time.
+type Timeseries struct {
+	Dates  []time.Time
+	Series map[string][]*big.Float
+}
+
+// NewTimeseries builds a Timeseries from the date-keyed rates returned by
+// TimeseriesAll/Multiple/Single.
+func NewTimeseries(raw map[string]map[string]*big.Float) (*Timeseries, error) {
+	if len(raw) == 0 {
+		return nil, ErrEmptyTimeseries
+	}
+
+	byDate := make(map[string]map[string]*big.Float, len(raw))
+	dates := make([]time.Time, 0, len(raw))
+	symbolSet := make(map[string]bool)
+	for dateStr, rates := range raw {
+		t, err := parseDate(dateStr)
+		if err != nil {
+			return nil, err
+		}
+		dates = append(dates, t)
+		byDate[dateStr] = rates
+		for symbol := range rates {
+			symbolSet[symbol] = true
+		}
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	series := make(map[string][]*big.Float, len(symbolSet))
+	for symbol := range symbolSet {
+		series[symbol] = make([]*big.Float, len(dates))
+	}
+	for i, d := range dates {
+		rates := byDate[d.Format("2006-01-02")]
+		for symbol := range symbolSet {
+			series[symbol][i] = rates[symbol]
+		}
+	}
+
+	return &Timeseries{Dates: dates, Series: series}, nil
+}
+
+// SMA returns the simple moving average of symbol over window ticks. The
+// first window-1 entries are nil, since there aren't enough prior ticks
+// yet to average.
+func (ts *Timeseries) SMA(symbol string, window int) []*big.Float {
+	values := ts.Series[symbol]
+	result := make([]*big.Float, len(values))
+	if window <= 0 {
+		return result
+	}
+
+	sum := new(big.Float)
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		sum.Add(sum, v)
+		if i >= window && values[i-window] != nil {
+			sum.Sub(sum, values[i-window])
+		}
+		if i >= window-1 {
+			result[i] = new(big.Float).Quo(new(big.Float).Copy(sum), big.NewFloat(float64(window)))
+		}
+	}
+	return result
+}
+
+// EMA returns the exponential moving average of symbol with smoothing
+// factor alpha, seeded with the first tick's value.
+func (ts *Timeseries) EMA(symbol string, alpha float64) []*big.Float {
+	values := ts.Series[symbol]
+	result := make([]*big.Float, len(values))
+	if len(values) == 0 {
+		return result
+	}
+
+	a := big.NewFloat(alpha)
+	oneMinusA := new(big.Float).Sub(big.NewFloat(1), a)
+
+	prev := values[0]
+	result[0] = prev
+	for i := 1; i < len(values); i++ {
+		if values[i] == nil || prev == nil {
+			result[i] = prev
+			continue
+		}
+		cur := new(big.Float).Add(new(big.Float).Mul(a, values[i]), new(big.Float).Mul(oneMinusA, prev))
+		result[i] = cur
+		prev = cur
+	}
+	return result
+}
+
+// Volatility returns the standard deviation of symbol's daily log-returns.
+func (ts *Timeseries) Volatility(symbol string) float64 {
+	values := ts.Series[symbol]
+
+	var logReturns []float64
+	for i := 1; i < len(values); i++ {
+		prev, cur := values[i-1], values[i]
+		if prev == nil || cur == nil || prev.Sign() <= 0 || cur.Sign() <= 0 {
+			continue
+		}
+		p, _ := prev.Float64()
+		c, _ := cur.Float64()
+		logReturns = append(logReturns, math.Log(c/p))
+	}
+	if len(logReturns) == 0 {
+		return 0
+	}
+
+	var mean float64
+	for _, r := range logReturns {
+		mean += r
+	}
+	mean /= float64(len(logReturns))
+
+	var variance float64
+	for _, r := range logReturns {
+		variance += (r - mean) * (r - mean)
+	}
+	variance /= float64(len(logReturns))
+
+	return math.Sqrt(variance)
+}
+
+// MaxDrawdown returns the largest peak-to-trough fractional decline in
+// symbol's rate, e.g. 0.2 for a 20% drop from the running high.
+func (ts *Timeseries) MaxDrawdown(symbol string) float64 {
+	values := ts.Series[symbol]
+
+	var peak float64
+	var maxDrawdown float64
+	havePeak := false
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		f, _ := v.Float64()
+		if !havePeak || f > peak {
+			peak = f
+			havePeak = true
+			continue
+		}
+		if peak > 0 {
+			if drawdown := (peak - f) / peak; drawdown > maxDrawdown {
+				maxDrawdown = drawdown
+			}
+		}
+	}
+	return maxDrawdown
+}
+
+// OHLC is one open/high/low/close bucket produced by Resample.
+type OHLC struct {
+	Start, End             time.Time
+	Open, High, Low, Close *big.Float
+}
+
+// Resample buckets symbol's daily ticks into period-wide OHLC candles.
+func (ts *Timeseries) Resample(symbol string, period time.Duration) []OHLC {
+	values := ts.Series[symbol]
+
+	var buckets []OHLC
+	var bucketStart time.Time
+	var cur *OHLC
+	for i, d := range ts.Dates {
+		v := values[i]
+		if v == nil {
+			continue
+		}
+		if cur == nil || d.Sub(bucketStart) >= period {
+			if cur != nil {
+				buckets = append(buckets, *cur)
+			}
+			bucketStart = d
+			cur = &OHLC{Start: d, End: d, Open: v, High: v, Low: v, Close: v}
+			continue
+		}
+		if v.Cmp(cur.High) > 0 {
+			cur.High = v
+		}
+		if v.Cmp(cur.Low) < 0 {
+			cur.Low = v
+		}
+		cur.Close = v
+		cur.End = d
+	}
+	if cur != nil {
+		buckets = append(buckets, *cur)
+	}
+	return buckets
+}
+
+// TimeseriesSeries is TimeseriesMultiple reshaped into a Timeseries.
+func (exchange *Exchange) TimeseriesSeries(start string, end string, symbols []string) (*Timeseries, error) {
+	return exchange.TimeseriesSeriesContext(context.Background(), start, end, symbols)
+}
+
+// TimeseriesSeriesContext is TimeseriesSeries with ctx threaded down to
+// the underlying HTTP request, so callers can cancel it or attach a
+// deadline.
+func (exchange *Exchange) TimeseriesSeriesContext(ctx context.Context, start string, end string, symbols []string) (*Timeseries, error) {
+	raw, err := exchange.TimeseriesMultipleContext(ctx, start, end, symbols)
+	if err != nil {
+		return nil, err
+	}
+	return NewTimeseries(raw)
+}
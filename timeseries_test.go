@@ -0,0 +1,167 @@
+package exchange
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func rawTimeseries(dates []string, symbol string, values []float64) map[string]map[string]*big.Float {
+	raw := make(map[string]map[string]*big.Float, len(dates))
+	for i, d := range dates {
+		raw[d] = map[string]*big.Float{symbol: big.NewFloat(values[i])}
+	}
+	return raw
+}
+
+func TestNewTimeseriesSortsDatesAndAlignsSeries(t *testing.T) {
+	raw := rawTimeseries([]string{"2024-01-03", "2024-01-01", "2024-01-02"}, "EUR", []float64{1.2, 1.0, 1.1})
+
+	ts, err := NewTimeseries(raw)
+	if err != nil {
+		t.Fatalf("NewTimeseries: %v", err)
+	}
+
+	if len(ts.Dates) != 3 {
+		t.Fatalf("len(Dates) = %d, want 3", len(ts.Dates))
+	}
+	for i := 0; i < len(ts.Dates)-1; i++ {
+		if !ts.Dates[i].Before(ts.Dates[i+1]) {
+			t.Fatalf("Dates not sorted: %v", ts.Dates)
+		}
+	}
+
+	want := []float64{1.0, 1.1, 1.2}
+	for i, v := range ts.Series["EUR"] {
+		got, _ := v.Float64()
+		if got != want[i] {
+			t.Errorf("Series[EUR][%d] = %v, want %v", i, got, want[i])
+		}
+	}
+}
+
+func TestNewTimeseriesEmpty(t *testing.T) {
+	if _, err := NewTimeseries(nil); err != ErrEmptyTimeseries {
+		t.Errorf("err = %v, want ErrEmptyTimeseries", err)
+	}
+}
+
+func TestTimeseriesSMA(t *testing.T) {
+	raw := rawTimeseries(
+		[]string{"2024-01-01", "2024-01-02", "2024-01-03", "2024-01-04"},
+		"EUR", []float64{1, 2, 3, 4},
+	)
+	ts, err := NewTimeseries(raw)
+	if err != nil {
+		t.Fatalf("NewTimeseries: %v", err)
+	}
+
+	sma := ts.SMA("EUR", 2)
+	if sma[0] != nil {
+		t.Errorf("sma[0] = %v, want nil (not enough ticks yet)", sma[0])
+	}
+	wantFrom1 := []float64{1.5, 2.5, 3.5}
+	for i, want := range wantFrom1 {
+		got, _ := sma[i+1].Float64()
+		if got != want {
+			t.Errorf("sma[%d] = %v, want %v", i+1, got, want)
+		}
+	}
+}
+
+func TestTimeseriesEMASeedsFirstValue(t *testing.T) {
+	raw := rawTimeseries([]string{"2024-01-01", "2024-01-02"}, "EUR", []float64{1, 3})
+	ts, err := NewTimeseries(raw)
+	if err != nil {
+		t.Fatalf("NewTimeseries: %v", err)
+	}
+
+	ema := ts.EMA("EUR", 0.5)
+	if got, _ := ema[0].Float64(); got != 1 {
+		t.Errorf("ema[0] = %v, want 1 (seeded with first tick)", got)
+	}
+	if got, _ := ema[1].Float64(); got != 2 { // 0.5*3 + 0.5*1
+		t.Errorf("ema[1] = %v, want 2", got)
+	}
+}
+
+func TestTimeseriesVolatility(t *testing.T) {
+	raw := rawTimeseries(
+		[]string{"2024-01-01", "2024-01-02", "2024-01-03"},
+		"EUR", []float64{1, 1, 1},
+	)
+	ts, err := NewTimeseries(raw)
+	if err != nil {
+		t.Fatalf("NewTimeseries: %v", err)
+	}
+	if v := ts.Volatility("EUR"); v != 0 {
+		t.Errorf("Volatility of a flat series = %v, want 0", v)
+	}
+
+	raw2 := rawTimeseries(
+		[]string{"2024-01-01", "2024-01-02", "2024-01-03"},
+		"EUR", []float64{1, math.E, 1},
+	)
+	ts2, err := NewTimeseries(raw2)
+	if err != nil {
+		t.Fatalf("NewTimeseries: %v", err)
+	}
+	if v := ts2.Volatility("EUR"); v <= 0 {
+		t.Errorf("Volatility of a moving series = %v, want > 0", v)
+	}
+}
+
+func TestTimeseriesMaxDrawdown(t *testing.T) {
+	raw := rawTimeseries(
+		[]string{"2024-01-01", "2024-01-02", "2024-01-03", "2024-01-04"},
+		"EUR", []float64{10, 20, 10, 15},
+	)
+	ts, err := NewTimeseries(raw)
+	if err != nil {
+		t.Fatalf("NewTimeseries: %v", err)
+	}
+
+	want := 0.5 // peak 20 -> trough 10
+	if got := ts.MaxDrawdown("EUR"); got != want {
+		t.Errorf("MaxDrawdown = %v, want %v", got, want)
+	}
+}
+
+func TestTimeseriesResampleBuckets(t *testing.T) {
+	raw := rawTimeseries(
+		[]string{"2024-01-01", "2024-01-02", "2024-01-03", "2024-01-04"},
+		"EUR", []float64{1, 3, 2, 5},
+	)
+	ts, err := NewTimeseries(raw)
+	if err != nil {
+		t.Fatalf("NewTimeseries: %v", err)
+	}
+
+	buckets := ts.Resample("EUR", 48*time.Hour)
+	if len(buckets) != 2 {
+		t.Fatalf("len(buckets) = %d, want 2", len(buckets))
+	}
+
+	first := buckets[0]
+	if o, _ := first.Open.Float64(); o != 1 {
+		t.Errorf("buckets[0].Open = %v, want 1", o)
+	}
+	if h, _ := first.High.Float64(); h != 3 {
+		t.Errorf("buckets[0].High = %v, want 3", h)
+	}
+	if l, _ := first.Low.Float64(); l != 1 {
+		t.Errorf("buckets[0].Low = %v, want 1", l)
+	}
+	if c, _ := first.Close.Float64(); c != 3 {
+		t.Errorf("buckets[0].Close = %v, want 3", c)
+	}
+
+	second := buckets[1]
+	if o, _ := second.Open.Float64(); o != 2 {
+		t.Errorf("buckets[1].Open = %v, want 2", o)
+	}
+	if c, _ := second.Close.Float64(); c != 5 {
+		t.Errorf("buckets[1].Close = %v, want 5", c)
+	}
+}